@@ -37,11 +37,17 @@
 package abelian
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
 )
 
+// ErrNotInvertible is the error returned when an operation
+// requires inverses but the Set of the group does not provide them.
+var ErrNotInvertible = errors.New("group set does not implement prop.Invertible")
+
 // Group is a generic abelian group: 〈S, op〉.
 // S is the (possibly infinite) set and op is the binary
 // operation that can be applied to elements of S to obtain
@@ -55,14 +61,71 @@ type Group struct {
 
 	// Op is a binary operation on elements of the group.
 	Op set.BinOp
+
+	desc *set.Operation // description of Op, if given by Describe.
 }
 
-// String returns a formal string representation of the group.
+// String returns a formal string representation of the group,
+// e.g. 〈ℤxℤ, +, (0,0)〉, using the description of the Op given
+// by Describe or registered with set.RegisterOp.
 func (g Group) String() string {
-	return fmt.Sprintf("〈%s, %T, %s〉", g.Set.Name(), g.Op, g.Set.Identity())
+	op := set.DescribeOp(g.Op)
+	if g.desc != nil {
+		op = *g.desc
+	}
+	id := op.Identity
+	if id == "" {
+		id = g.Set.Identity().String()
+	}
+	return fmt.Sprintf("〈%s, %s, %s〉", g.Set.Name(), op.Symbol, id)
 }
 
 // New return a new instance of abelian group.
 func New(s set.Set, op set.BinOp) Group {
 	return Group{Set: s, Op: op}
 }
+
+// Describe returns the group g with the description o of its Op,
+// e.g. for an Op which is a function literal and cannot be registered
+// with set.RegisterOp.
+func (g Group) Describe(o set.Operation) Group {
+	g.desc = &o
+	return g
+}
+
+// Capabilities returns the property interfaces of package prop
+// which the Set of g implements, e.g.
+//
+//	if g.Capabilities().Has(prop.IsInvertible | prop.IsFinite) {
+//		...
+//	}
+func (g Group) Capabilities() prop.Properties {
+	return prop.Of(g.Set)
+}
+
+// Sub returns x·y⁻¹, which is x - y for additive groups.
+//
+// The Set of g must implement prop.Invertible,
+// otherwise ErrNotInvertible is returned.
+func (g Group) Sub(x, y set.Elem) (set.Elem, error) {
+	inv, ok := g.Set.(prop.Invertible)
+	if !ok {
+		return nil, ErrNotInvertible
+	}
+	return g.Op(x, inv.Inverse(y)), nil
+}
+
+// Metric returns the distance function d(x, y) = ‖x - y‖
+// induced by norm, where x - y is computed by g.Sub.
+//
+// The Set of g must implement prop.Invertible,
+// otherwise ErrNotInvertible is returned.
+func (g Group) Metric(norm func(set.Elem) int) (set.Metric, error) {
+	if _, ok := g.Set.(prop.Invertible); !ok {
+		return nil, ErrNotInvertible
+	}
+	return func(x, y set.Elem) int {
+		d, _ := g.Sub(x, y)
+		return norm(d)
+	}, nil
+}
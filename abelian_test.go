@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/internal/settest"
 	"github.com/nickng/abelian/set"
 	"github.com/nickng/abelian/set/prop"
 )
@@ -60,3 +61,79 @@ func TestStrictOrder(t *testing.T) {
 		t.Errorf("Set %s is not strictly ordered", g.Set.Name())
 	}
 }
+
+func TestGroupSub(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	z, err := g.Sub(s.Tuple(1, 2), s.Tuple(2, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := s.Tuple(-1, -1); want.Compare(z) != 0 {
+		t.Errorf("expected %s but got %s", want, z)
+	}
+
+	d, err := g.Metric(set.L1.Of)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 7, d(s.Tuple(1, 2), s.Tuple(4, -2)); want != got {
+		t.Errorf("expected distance %d but got %d", want, got)
+	}
+}
+
+func TestGroupSubNotInvertible(t *testing.T) {
+	s := set.NewIntTuple(1)
+	g := abelian.New(settest.NonInvertible{IntTupleSet: s}, s.Add)
+	if _, err := g.Sub(s.Tuple(1), s.Tuple(2)); err != abelian.ErrNotInvertible {
+		t.Errorf("expected %v but got %v", abelian.ErrNotInvertible, err)
+	}
+}
+
+func TestFreeAbelianGroup(t *testing.T) {
+	s := set.NewFreeAbelian()
+	g := abelian.New(s, s.Add)
+	x := s.Sum(set.Term{Coef: 3, Sym: "a"}, set.Term{Coef: -2, Sym: "b"})
+	y := s.Basis("b")
+	if want, got := "3·a - b", g.Op(x, y).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	z, err := g.Sub(x, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.Compare(g.Identity()) != 0 {
+		t.Errorf("expected %s - %s to be the identity but got %s", x, x, z)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	if caps := g.Capabilities(); !caps.Has(prop.IsInvertible|prop.IsTotallyOrdered|prop.IsTorsionFree) || caps.Has(prop.IsFinite) {
+		t.Errorf("unexpected capabilities of %s: %v", g.Set.Name(), caps)
+	}
+	m := set.NewMod(3)
+	if want, got := prop.IsInvertible|prop.IsFinite|prop.IsCountable, abelian.New(m, m.Add).Capabilities(); want != got {
+		t.Errorf("expected capabilities of %s to be %v but got %v", m.Name(), want, got)
+	}
+}
+
+func TestString(t *testing.T) {
+	s := set.NewIntTuple(2)
+	if want, got := "〈ℤxℤ, +, (0,0)〉", abelian.New(s, s.Add).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	p := set.NewPoly(1)
+	if want, got := "〈ℤ[x], +, 0〉", abelian.New(p, p.Add).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	xor := func(x, y set.Elem) set.Elem { return set.NewMod(2).Add(x, y) }
+	g := abelian.New(set.NewMod(2), xor).Describe(set.Operation{Name: "exclusive or", Symbol: "⊕", Identity: "false"})
+	if want, got := "〈ℤ/2, ⊕, false〉", g.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if _, ok := set.LookupOp(xor); ok {
+		t.Errorf("expected the closure not to be registered")
+	}
+}
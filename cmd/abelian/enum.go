@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/nickng/abelian/set"
+)
+
+// enumCmd writes the tuples of an interval.
+func enumCmd(args []string, w, stderr io.Writer) error {
+	fs := flag.NewFlagSet("enum", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	order := fs.String("order", "lex", "order of the tuples: lex, colex or reverse")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+	iv, err := interval(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	tuples, err := enumerate(iv, *order)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "text":
+		for _, x := range tuples {
+			if _, err := fmt.Fprintln(w, x); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		return json.NewEncoder(w).Encode(tuples)
+	case "csv":
+		return writeCSV(w, iv.Lo().Size(), tuples)
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+// enumerate returns the tuples of iv in the given order.
+func enumerate(iv set.IntTupleInterval, order string) ([]set.IntTuple, error) {
+	tuples := make([]set.IntTuple, 0, iv.Cardinality())
+	if iv.Cardinality() > 0 {
+		for _, x := range iv.Slice() {
+			tuples = append(tuples, x.(set.IntTuple))
+		}
+	}
+	switch order {
+	case "lex":
+	case "colex":
+		sort.SliceStable(tuples, func(i, j int) bool {
+			x, y := tuples[i], tuples[j]
+			for k := x.Size() - 1; k >= 0; k-- {
+				if x[k] != y[k] {
+					return x[k] < y[k]
+				}
+			}
+			return false
+		})
+	case "reverse":
+		for i, j := 0, len(tuples)-1; i < j; i, j = i+1, j-1 {
+			tuples[i], tuples[j] = tuples[j], tuples[i]
+		}
+	default:
+		return nil, fmt.Errorf("unknown order %q", order)
+	}
+	return tuples, nil
+}
+
+// writeCSV writes the tuples as CSV with a header x1,...,xn.
+func writeCSV(w io.Writer, size int, tuples []set.IntTuple) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, size)
+	for i := range record {
+		record[i] = "x" + strconv.Itoa(i+1)
+	}
+	cw.Write(record)
+	for _, x := range tuples {
+		for i, v := range x {
+			record[i] = strconv.Itoa(v)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// countCmd writes the cardinality of an interval.
+func countCmd(args []string, w io.Writer) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	iv, err := interval(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, iv.Cardinality())
+	return err
+}
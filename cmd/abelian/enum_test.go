@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEnum(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"(0,0)", "(1,1)"}, "(0,0)\n(0,1)\n(1,0)\n(1,1)\n"},
+		{[]string{"-order", "colex", "(0,0)", "(1,1)"}, "(0,0)\n(1,0)\n(0,1)\n(1,1)\n"},
+		{[]string{"-order", "reverse", "1", "3"}, "3\n2\n1\n"},
+		{[]string{"-format", "json", "(0,0)", "(0,1)"}, "[[0,0],[0,1]]\n"},
+		{[]string{"-format", "json", "(1,0)", "(0,1)"}, "[]\n"},
+		{[]string{"-format", "csv", "(0,-1)", "(0,0)"}, "x1,x2\n0,-1\n0,0\n"},
+	}
+	for _, test := range tests {
+		got, err := runCmd(t, "", append([]string{"enum"}, test.args...)...)
+		if err != nil {
+			t.Errorf("cannot enumerate %v: %v", test.args, err)
+			continue
+		}
+		if want := test.want; want != got {
+			t.Errorf("expected enum %v to write %q but got %q", test.args, want, got)
+		}
+	}
+	if _, err := runCmd(t, "", "enum", "-format", "xml", "0", "1"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
+
+func TestCount(t *testing.T) {
+	if want, got := "12\n", mustRun(t, "count", "(0,0,0)", "(1,2,1)"); want != got {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+	if want, got := "0\n", mustRun(t, "count", "(0,3)", "(1,2)"); want != got {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+}
+
+func mustRun(t *testing.T, args ...string) string {
+	out, err := runCmd(t, "", args...)
+	if err != nil {
+		t.Fatalf("cannot run %v: %v", args, err)
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/expr"
+	"github.com/nickng/abelian/set"
+)
+
+// evalCmd evaluates an expression in ℤⁿ.
+func evalCmd(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	x, err := eval(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, x)
+	return err
+}
+
+// eval evaluates text in ℤⁿ, where n is the size of the first tuple.
+func eval(text string) (set.Elem, error) {
+	e, err := expr.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := size(e)
+	if !ok {
+		return nil, errors.New("cannot evaluate expression without tuples")
+	}
+	s := set.NewIntTuple(n)
+	return e.Eval(abelian.New(s, s.Add), nil)
+}
+
+// size returns the size of the first tuple literal in e.
+func size(e expr.Expr) (int, bool) {
+	switch e := e.(type) {
+	case expr.Literal:
+		x, err := set.ParseIntTuple(e.Elem)
+		return x.Size(), err == nil
+	case expr.Neg:
+		return size(e.X)
+	case expr.Scale:
+		return size(e.X)
+	case expr.Binary:
+		if n, ok := size(e.X); ok {
+			return n, ok
+		}
+		return size(e.Y)
+	case expr.Sum:
+		for _, e := range []expr.Expr{e.Lo, e.Hi, e.Body} {
+			if n, ok := size(e); ok {
+				return n, ok
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"(1,2) + (3,4) - (0,1)"}, "(4,5)\n"},
+		{[]string{"(1,-2)", "-", "(-1,-2)"}, "(2,0)\n"},
+		{[]string{"-3 + 5 - -1"}, "3\n"},
+		{[]string{"(0,0,0)"}, "(0,0,0)\n"},
+		{[]string{"(1,2) + 3*(0,1) - (4,4)"}, "(-3,1)\n"},
+		{[]string{"sum(x in (0,0)..(1,1), x)"}, "(2,2)\n"},
+	}
+	for _, test := range tests {
+		got, err := runCmd(t, "", append([]string{"eval"}, test.args...)...)
+		if err != nil {
+			t.Errorf("cannot evaluate %v: %v", test.args, err)
+			continue
+		}
+		if want := test.want; want != got {
+			t.Errorf("expected %v to evaluate to %q but got %q", test.args, want, got)
+		}
+	}
+}
+
+func TestEvalError(t *testing.T) {
+	for _, expr := range []string{"(1,2) + (1,2,3)", "(1,2) +", "(1,x)", "(1,2) (3,4)", "x + y"} {
+		if _, err := runCmd(t, "", "eval", expr); err == nil {
+			t.Errorf("expected error evaluating %q", expr)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// editor is a lineReader with line editing for terminals in raw mode.
+//
+// It supports backspace, Ctrl-C to discard the line, Ctrl-D to end the
+// input on an empty line, the up and down arrows to move through the
+// history, and tab to complete the name before the cursor.
+type editor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  func() []string
+	complete func(prefix string) []string
+}
+
+// Control keys.
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyEnter     = '\r'
+	keyNewline   = '\n'
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// ReadLine reads a line after writing the prompt.
+func (e *editor) ReadLine(prompt string) (string, error) {
+	var line []rune
+	hist := e.history()
+	h := len(hist) // h is the position in the history, len(hist) for the new line.
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r\x1b[K%s%s", prompt, string(line))
+	}
+	redraw()
+	for {
+		c, err := e.in.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch c {
+		case keyEnter, keyNewline:
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			line, h = nil, len(hist)
+			redraw()
+		case keyCtrlD:
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				redraw()
+			}
+		case keyTab:
+			line = e.completeLine(line, prompt)
+			redraw()
+		case keyEscape:
+			seq := make([]byte, 2)
+			if _, err := io.ReadFull(e.in, seq); err != nil {
+				return "", err
+			}
+			if seq[0] != '[' {
+				continue
+			}
+			switch seq[1] {
+			case 'A': // up
+				if h > 0 {
+					h--
+					line = []rune(hist[h])
+				}
+			case 'B': // down
+				if h < len(hist) {
+					if h++; h == len(hist) {
+						line = nil
+					} else {
+						line = []rune(hist[h])
+					}
+				}
+			}
+			redraw()
+		default:
+			if c < ' ' {
+				continue // other control keys.
+			}
+			buf := []byte{c}
+			for !utf8.FullRune(buf) {
+				b, err := e.in.ReadByte()
+				if err != nil {
+					return "", err
+				}
+				buf = append(buf, b)
+			}
+			r, _ := utf8.DecodeRune(buf)
+			line = append(line, r)
+			fmt.Fprint(e.out, string(r))
+		}
+	}
+}
+
+// completeLine completes the name at the end of line to the longest
+// common prefix of the matching names, and lists the names if ambiguous.
+func (e *editor) completeLine(line []rune, prompt string) []rune {
+	start := len(line)
+	for start > 0 && (line[start-1] == '_' || unicode.IsLetter(line[start-1]) || unicode.IsDigit(line[start-1])) {
+		start--
+	}
+	prefix := string(line[start:])
+	names := e.complete(prefix)
+	if len(names) == 0 {
+		return line
+	}
+	common := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, common) {
+			_, size := utf8.DecodeLastRuneInString(common)
+			common = common[:len(common)-size]
+		}
+	}
+	if len(names) == 1 {
+		common += " "
+	} else if common == prefix {
+		fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(names, "  "))
+	}
+	return append(line[:start], []rune(common)...)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func newTestEditor(input string, history []string) (*editor, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &editor{
+		in:       bufio.NewReader(strings.NewReader(input)),
+		out:      &out,
+		history:  func() []string { return history },
+		complete: newREPL(ioutil.Discard).complete,
+	}, &out
+}
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		input   string
+		history []string
+		want    string
+	}{
+		{"(1,2)\r", nil, "(1,2)"},
+		{"ℤx\x7f\r", nil, "ℤ"},
+		{"junk\x031+1\r", nil, "1+1"},
+		{"\x1b[A\x1b[A\r", []string{"a", "b"}, "a"},
+		{"\x1b[A\x1b[B\r", []string{"a", "b"}, ""},
+		{"his\t\r", nil, "history "},
+		{"let x = gr\t\r", nil, "let x = group"},
+	}
+	for _, test := range tests {
+		e, _ := newTestEditor(test.input, test.history)
+		got, err := e.ReadLine("> ")
+		if err != nil {
+			t.Errorf("cannot read %q: %v", test.input, err)
+			continue
+		}
+		if want := test.want; want != got {
+			t.Errorf("expected %q to read %q but got %q", test.input, want, got)
+		}
+	}
+}
+
+func TestEditorEOF(t *testing.T) {
+	e, _ := newTestEditor("\x04", nil)
+	if _, err := e.ReadLine("> "); err != io.EOF {
+		t.Errorf("expected EOF but got %v", err)
+	}
+}
+
+// Tests ambiguous completions are listed.
+func TestEditorCompleteList(t *testing.T) {
+	e, out := newTestEditor("group\t\r", nil)
+	if _, err := e.ReadLine("> "); err != nil {
+		t.Fatal(err)
+	}
+	if want := "\r\ngroup  groups\r\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("expected output to contain %q but got %q", want, out.String())
+	}
+}
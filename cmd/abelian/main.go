@@ -0,0 +1,100 @@
+// Command abelian performs quick computations in the groups of
+// integer tuples ℤⁿ without writing Go programs.
+//
+// Usage:
+//
+//	abelian eval EXPR
+//	abelian enum [-order lex|colex|reverse] [-format text|json|csv] LO HI
+//	abelian count LO HI
+//	abelian snf [ROW...]
+//	abelian repl
+//
+// eval evaluates an expression of tuples in ℤⁿ, e.g. "(1,2) + 3*(0,1) - (4,4)",
+// see package github.com/nickng/abelian/expr for the syntax.
+//
+// enum writes the tuples of the interval LO≤..≤HI, e.g. "(0,0)" "(2,2)",
+// in lexicographic (lex), colexicographic (colex) or reverse lexicographic
+// (reverse) order.
+//
+// count writes the number of tuples in the interval LO≤..≤HI.
+//
+// snf writes the Smith normal form of the relation matrix with the given
+// rows, or the rows read from standard input one per line, and the
+// quotient group the relations define.
+//
+// repl starts an interactive session to define groups, bind variables,
+// evaluate expressions, list intervals and query properties of groups.
+// Type help in the session for the commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/nickng/abelian/set"
+)
+
+const usage = `usage:
+	abelian eval EXPR
+	abelian enum [-order lex|colex|reverse] [-format text|json|csv] LO HI
+	abelian count LO HI
+	abelian snf [ROW...]
+	abelian repl
+`
+
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("abelian: ")
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if err == errUsage || err == flag.ErrHelp {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
+
+// run runs the subcommand in args.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "eval":
+		return evalCmd(args, stdout)
+	case "enum":
+		return enumCmd(args, stdout, stderr)
+	case "count":
+		return countCmd(args, stdout)
+	case "snf":
+		return snfCmd(args, stdin, stdout)
+	case "repl":
+		return replCmd(args, stdin, stdout)
+	case "help", "-h", "-help", "--help":
+		return flag.ErrHelp
+	}
+	return fmt.Errorf("unknown command %q", cmd)
+}
+
+// interval parses the bounds of an interval.
+func interval(lo, hi string) (set.IntTupleInterval, error) {
+	l, err := set.ParseIntTuple(lo)
+	if err != nil {
+		return set.IntTupleInterval{}, err
+	}
+	h, err := set.ParseIntTuple(hi)
+	if err != nil {
+		return set.IntTupleInterval{}, err
+	}
+	if l.Size() != h.Size() {
+		return set.IntTupleInterval{}, set.MismatchDimErr{Dim1: l.Size(), Dim2: h.Size()}
+	}
+	return set.NewIntTuple(l.Size()).Interval(l, h).(set.IntTupleInterval), nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runCmd runs the command line args with the given stdin and returns stdout.
+func runCmd(t *testing.T, stdin string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestRunUsage(t *testing.T) {
+	if _, err := runCmd(t, ""); err != errUsage {
+		t.Errorf("expected usage error but got %v", err)
+	}
+	if _, err := runCmd(t, "", "frobnicate"); err == nil {
+		t.Errorf("expected error for unknown command")
+	}
+	if _, err := runCmd(t, "", "count", "(0,0)"); err != errUsage {
+		t.Errorf("expected usage error but got %v", err)
+	}
+	if _, err := runCmd(t, "", "count", "(0,0)", "(1,1,1)"); err == nil {
+		t.Errorf("expected error for mismatched dimensions")
+	}
+}
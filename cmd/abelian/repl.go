@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"unicode"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/expr"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+const replHelp = `commands:
+	EXPR                 evaluate EXPR in the current group, e.g. (1,2) + 3*(0,1)
+	let NAME = EXPR      bind the value of EXPR to the variable NAME
+	group NAME = SPEC    define and use a group, e.g. Z^2, Z/6 or Z^2 x Z/3 x G
+	use NAME             use the group NAME
+	list LO..HI          list the members of an interval
+	list                 list the members of a finite group
+	props [NAME]         show the properties of a group
+	groups               list the groups
+	vars                 list the variables
+	history              list the history, !N repeats entry N and !! the last
+	help                 show this help
+	quit                 exit
+`
+
+// maxList is the maximum number of members written by list.
+const maxList = 100
+
+var replCommands = []string{"let", "group", "use", "list", "props", "groups", "vars", "history", "help", "quit", "sum", "in"}
+
+// lineReader reads lines of input.
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// scanReader is a lineReader without line editing, for non-terminal input.
+type scanReader struct {
+	*bufio.Scanner
+}
+
+func (r scanReader) ReadLine(prompt string) (string, error) {
+	if !r.Scan() {
+		if err := r.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.Text(), nil
+}
+
+// replCmd runs the interactive REPL, with line editing if stdin is a terminal.
+func replCmd(args []string, stdin io.Reader, w io.Writer) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	r := newREPL(w)
+	if f, ok := stdin.(*os.File); ok {
+		if restoreTerm, err := makeRaw(int(f.Fd())); err == nil {
+			var once sync.Once
+			restore := func() { once.Do(restoreTerm) }
+			defer restore()
+			// Restore the terminal on signals which would otherwise end
+			// the process without running deferred calls.
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, syscall.SIGHUP, syscall.SIGTERM)
+			defer signal.Stop(sigs)
+			go func() {
+				if _, ok := <-sigs; ok {
+					restore()
+					os.Exit(1)
+				}
+			}()
+			fmt.Fprint(w, "abelian REPL, type help for commands.\r\n")
+			e := &editor{in: bufio.NewReader(f), out: w, history: r.history, complete: r.complete}
+			return r.run(e, crlfWriter{w})
+		}
+	}
+	return r.run(scanReader{bufio.NewScanner(stdin)}, w)
+}
+
+// crlfWriter writes \n as \r\n for terminals in raw mode.
+type crlfWriter struct {
+	io.Writer
+}
+
+func (w crlfWriter) Write(p []byte) (int, error) {
+	_, err := w.Writer.Write([]byte(strings.Replace(string(p), "\n", "\r\n", -1)))
+	return len(p), err
+}
+
+// repl is the state of a REPL session.
+type repl struct {
+	groups  map[string]abelian.Group
+	current string
+	env     expr.Env
+	hist    []string
+	out     io.Writer
+}
+
+func newREPL(w io.Writer) *repl {
+	z := set.NewIntTuple(1)
+	return &repl{
+		groups:  map[string]abelian.Group{"Z": abelian.New(z, z.Add)},
+		current: "Z",
+		env:     expr.Env{},
+		out:     w,
+	}
+}
+
+// run reads and executes lines from r until the end of input or quit.
+func (r *repl) run(lr lineReader, w io.Writer) error {
+	r.out = w
+	for {
+		line, err := lr.ReadLine(r.current + "> ")
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		quit, err := r.safeExec(line)
+		if err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// safeExec is exec which reports a panic while executing the line as an
+// error, so that the session continues and the terminal stays usable.
+func (r *repl) safeExec(line string) (quit bool, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			quit, err = false, fmt.Errorf("%v", v)
+		}
+	}()
+	return r.exec(line)
+}
+
+// exec executes a line, and returns true if the REPL should quit.
+func (r *repl) exec(line string) (quit bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false, nil
+	}
+	if strings.HasPrefix(line, "!") {
+		if line, err = r.recall(line); err != nil {
+			return false, err
+		}
+		fmt.Fprintln(r.out, line)
+	}
+	r.hist = append(r.hist, line)
+	cmd, rest := line, ""
+	if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+		cmd, rest = line[:i], strings.TrimSpace(line[i:])
+	}
+	switch cmd {
+	case "quit", "exit":
+		return true, nil
+	case "help":
+		fmt.Fprint(r.out, replHelp)
+	case "let":
+		return false, r.let(rest)
+	case "group":
+		return false, r.defineGroup(rest)
+	case "use":
+		if _, ok := r.groups[rest]; !ok {
+			return false, fmt.Errorf("undefined group %s", rest)
+		}
+		r.current = rest
+	case "list":
+		return false, r.list(rest)
+	case "props":
+		return false, r.props(rest)
+	case "groups":
+		for _, name := range r.groupNames() {
+			mark := " "
+			if name == r.current {
+				mark = "*"
+			}
+			fmt.Fprintf(r.out, "%s %s = %s\n", mark, name, r.groups[name])
+		}
+	case "vars":
+		for _, name := range r.varNames() {
+			fmt.Fprintf(r.out, "%s = %v\n", name, r.env[name])
+		}
+	case "history":
+		for i, h := range r.hist {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, h)
+		}
+	default:
+		x, err := expr.Eval(r.group(), line, r.env)
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintln(r.out, x)
+	}
+	return false, nil
+}
+
+// recall returns the history entry of !N or !!.
+func (r *repl) recall(line string) (string, error) {
+	if len(r.hist) == 0 {
+		return "", fmt.Errorf("no history")
+	}
+	if line == "!!" {
+		return r.hist[len(r.hist)-1], nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 || n > len(r.hist) {
+		return "", fmt.Errorf("no history entry %s", line[1:])
+	}
+	return r.hist[n-1], nil
+}
+
+// history returns the lines executed so far.
+func (r *repl) history() []string {
+	return r.hist
+}
+
+func (r *repl) group() abelian.Group {
+	return r.groups[r.current]
+}
+
+// let binds the value of an expression: NAME = EXPR.
+func (r *repl) let(def string) error {
+	name, text, err := definition(def)
+	if err != nil {
+		return err
+	}
+	x, err := expr.Eval(r.group(), text, r.env)
+	if err != nil {
+		return err
+	}
+	r.env[name] = x
+	fmt.Fprintf(r.out, "%s = %v\n", name, x)
+	return nil
+}
+
+// defineGroup defines and uses a group: NAME = SPEC.
+func (r *repl) defineGroup(def string) error {
+	name, spec, err := definition(def)
+	if err != nil {
+		return err
+	}
+	if strings.ContainsAny(name, "x×") {
+		return fmt.Errorf("invalid group name %s: x separates factors", name)
+	}
+	g, err := r.parseGroup(spec)
+	if err != nil {
+		return err
+	}
+	r.groups[name], r.current = g, name
+	fmt.Fprintf(r.out, "%s = %s\n", name, g)
+	return nil
+}
+
+// definition splits "NAME = TEXT".
+func definition(def string) (name, text string, err error) {
+	i := strings.IndexByte(def, '=')
+	if i < 0 {
+		return "", "", fmt.Errorf("expecting NAME = ... but got %q", def)
+	}
+	name, text = strings.TrimSpace(def[:i]), strings.TrimSpace(def[i+1:])
+	if !isIdent(name) {
+		return "", "", fmt.Errorf("invalid name %q", name)
+	}
+	return name, text, nil
+}
+
+func isIdent(name string) bool {
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !(i > 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != "" && name != "sum"
+}
+
+// parseGroup parses a product of factors separated by x or ×, where each
+// factor is ℤ, ℤ^n, ℤ/n (or with Z for ℤ) or the name of a group.
+func (r *repl) parseGroup(spec string) (abelian.Group, error) {
+	factors := strings.FieldsFunc(spec, func(c rune) bool { return c == 'x' || c == '×' })
+	if len(factors) == 0 {
+		return abelian.Group{}, fmt.Errorf("expecting group but got %q", spec)
+	}
+	gs := make([]abelian.Group, len(factors))
+	dim, allInts := 0, true
+	for i, f := range factors {
+		f = strings.TrimSpace(f)
+		base := strings.TrimPrefix(strings.TrimPrefix(f, "ℤ"), "Z")
+		switch {
+		case base == f || base != "" && base[0] != '^' && base[0] != '/':
+			g, ok := r.groups[f]
+			if !ok {
+				return abelian.Group{}, fmt.Errorf("undefined group %s", f)
+			}
+			gs[i], allInts = g, false
+		case base == "":
+			s := set.NewIntTuple(1)
+			gs[i], dim = abelian.New(s, s.Add), dim+1
+		case base[0] == '^':
+			n, err := strconv.Atoi(base[1:])
+			if err != nil || n < 1 {
+				return abelian.Group{}, fmt.Errorf("invalid dimension in %s", f)
+			}
+			s := set.NewIntTuple(n)
+			gs[i], dim = abelian.New(s, s.Add), dim+n
+		case base[0] == '/':
+			n, err := strconv.Atoi(base[1:])
+			if err != nil || n < 1 {
+				return abelian.Group{}, fmt.Errorf("invalid modulus in %s", f)
+			}
+			s := set.NewMod(n)
+			gs[i], allInts = abelian.New(s, s.Add), false
+		}
+	}
+	if len(gs) == 1 {
+		return gs[0], nil
+	}
+	if allInts { // ℤ^m x ℤ^n is ℤ^(m+n).
+		s := set.NewIntTuple(dim)
+		return abelian.New(s, s.Add), nil
+	}
+	return abelian.Product(gs...), nil
+}
+
+// list writes the members of the interval LO..HI, or of the group if
+// rng is empty.
+func (r *repl) list(rng string) error {
+	g := r.group()
+	var members set.Enumerable
+	if rng == "" {
+		e, ok := g.Set.(set.Enumerable)
+		if !ok {
+			return fmt.Errorf("cannot list %s: set is not enumerable, use list LO..HI", g.Name())
+		}
+		members = e
+	} else {
+		ord, ok := g.Set.(prop.PartialOrdered)
+		if !ok {
+			return fmt.Errorf("cannot list intervals of %s: set is not ordered", g.Name())
+		}
+		i := strings.Index(rng, "..")
+		if i < 0 {
+			return fmt.Errorf("expecting LO..HI but got %q", rng)
+		}
+		lo, err := expr.Eval(g, rng[:i], r.env)
+		if err != nil {
+			return err
+		}
+		hi, err := expr.Eval(g, rng[i+2:], r.env)
+		if err != nil {
+			return err
+		}
+		// The set package exits on bounds which are not members,
+		// so check them before creating the interval.
+		for _, x := range []set.Elem{lo, hi} {
+			if !g.IsIn(x) {
+				return fmt.Errorf("cannot list %s..%s: %v is not a member of %s", lo, hi, x, g.Name())
+			}
+		}
+		members = ord.Interval(lo, hi)
+	}
+	card, known := cardinality(members)
+	if known && card == 0 {
+		return nil
+	}
+	it := members.Enumerate()
+	for n := 0; ; n++ {
+		if n == maxList {
+			if known {
+				fmt.Fprintf(r.out, "... (%d more)\n", card-n)
+			} else {
+				fmt.Fprintln(r.out, "...")
+			}
+			return nil
+		}
+		next, more := it.Next()
+		if next != nil {
+			fmt.Fprintln(r.out, next)
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+func cardinality(x interface{}) (int, bool) {
+	if c, ok := x.(interface{ Cardinality() int }); ok {
+		return c.Cardinality(), true
+	}
+	return 0, false
+}
+
+// props writes the properties of the named or the current group.
+func (r *repl) props(name string) error {
+	if name == "" {
+		name = r.current
+	}
+	g, ok := r.groups[name]
+	if !ok {
+		return fmt.Errorf("undefined group %s", name)
+	}
+	_, partial := g.Set.(prop.PartialOrdered)
+	_, strict := g.Set.(prop.StrictOrdered)
+	_, invertible := g.Set.(prop.Invertible)
+	_, enumerable := g.Set.(set.Enumerable)
+	fmt.Fprintf(r.out, "group:       %s\n", g)
+	fmt.Fprintf(r.out, "identity:    %v\n", g.Identity())
+	fmt.Fprintf(r.out, "ordered:     %t\n", partial)
+	fmt.Fprintf(r.out, "strict:      %t\n", strict)
+	fmt.Fprintf(r.out, "invertible:  %t\n", invertible)
+	fmt.Fprintf(r.out, "enumerable:  %t\n", enumerable)
+	fmt.Fprintf(r.out, "properties:  %v\n", g.Capabilities())
+	if card, ok := cardinality(g.Set); ok {
+		fmt.Fprintf(r.out, "cardinality: %d\n", card)
+	}
+	if n, ok := rank(g); ok && n == prop.InfiniteRank {
+		fmt.Fprintf(r.out, "rank:        ∞\n")
+	} else if ok {
+		fmt.Fprintf(r.out, "rank:        %d\n", n)
+	}
+	return nil
+}
+
+// rank returns the torsion-free rank of g, if known,
+// which is prop.InfiniteRank if the rank is infinite.
+func rank(g abelian.Group) (int, bool) {
+	caps := g.Capabilities()
+	switch {
+	case caps.Has(prop.IsTorsionFree):
+		return g.Set.(prop.TorsionFree).Rank(), true
+	case caps.Has(prop.IsFinite): // every element has finite order.
+		return 0, true
+	}
+	if gs, ok := g.Factors(); ok {
+		sum := 0
+		for _, g := range gs {
+			n, ok := rank(g)
+			if !ok {
+				return 0, false
+			}
+			if n == prop.InfiniteRank {
+				return prop.InfiniteRank, true
+			}
+			sum += n
+		}
+		return sum, true
+	}
+	return 0, false
+}
+
+func (r *repl) groupNames() []string {
+	names := make([]string, 0, len(r.groups))
+	for name := range r.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *repl) varNames() []string {
+	names := make([]string, 0, len(r.env))
+	for name := range r.env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// complete returns the names which start with prefix.
+func (r *repl) complete(prefix string) []string {
+	var matches []string
+	for _, names := range [][]string{replCommands, r.groupNames(), r.varNames()} {
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				matches = append(matches, name)
+			}
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
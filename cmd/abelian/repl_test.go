@@ -0,0 +1,161 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// session runs the REPL with the lines of script as input.
+func session(t *testing.T, script ...string) string {
+	out, err := runCmd(t, strings.Join(script, "\n")+"\n", "repl")
+	if err != nil {
+		t.Fatalf("cannot run REPL: %v", err)
+	}
+	return out
+}
+
+func TestREPLEval(t *testing.T) {
+	got := session(t,
+		"1 + 2",
+		"group V = Z^2",
+		"let x = (1,2)",
+		"let y = 3*(0,1) - x",
+		"x + y",
+		"vars",
+		"undefined + x",
+	)
+	want := `3
+V = 〈ℤxℤ, +, (0,0)〉
+x = (1,2)
+y = (-1,1)
+(0,3)
+x = (1,2)
+y = (-1,1)
+error: cannot evaluate "undefined + x" at position 0: undefined variable undefined
+`
+	if want != got {
+		t.Errorf("expected output\n%s\nbut got\n%s", want, got)
+	}
+}
+
+func TestREPLGroups(t *testing.T) {
+	got := session(t,
+		"group C = Z/6",
+		"4 + 5",
+		"-1",
+		"group P = Z^2 x C",
+		"((1,2),5) + ((0,1),3)",
+		"group V = ZxZ",
+		"use P",
+		"groups",
+		"group Q = Z x W",
+	)
+	want := `C = 〈ℤ/6, +, 0〉
+3
+5
+P = 〈ℤxℤ × ℤ/6, ⊕, ((0,0),0)〉
+((1,3),2)
+V = 〈ℤxℤ, +, (0,0)〉
+  C = 〈ℤ/6, +, 0〉
+* P = 〈ℤxℤ × ℤ/6, ⊕, ((0,0),0)〉
+  V = 〈ℤxℤ, +, (0,0)〉
+  Z = 〈ℤ, +, 0〉
+error: undefined group W
+`
+	if want != got {
+		t.Errorf("expected output\n%s\nbut got\n%s", want, got)
+	}
+}
+
+func TestREPLList(t *testing.T) {
+	got := session(t,
+		"group V = Z^2",
+		"list (0,0)..(1,1)",
+		"list",
+		"group C = Z/3",
+		"list",
+		"use Z",
+		"list 0..1000",
+	)
+	want := `V = 〈ℤxℤ, +, (0,0)〉
+(0,0)
+(0,1)
+(1,0)
+(1,1)
+error: cannot list ℤxℤ: set is not enumerable, use list LO..HI
+C = 〈ℤ/3, +, 0〉
+0
+1
+2
+`
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("expected output to start with\n%s\nbut got\n%s", want, got)
+	}
+	if want := "99\n... (901 more)\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("expected output to end with %q but got\n%s", want, got)
+	}
+}
+
+func TestREPLProps(t *testing.T) {
+	got := session(t, "group P = Z^2 x Z/4", "props", "props Z")
+	for _, want := range []string{
+		"group:       〈ℤxℤ × ℤ/4, ⊕, ((0,0),0)〉\nidentity:    ((0,0),0)\nordered:     false\n",
+		"invertible:  true\nenumerable:  false\nproperties:  Invertible\nrank:        2\n",
+		"group:       〈ℤ, +, 0〉\nidentity:    0\nordered:     true\nstrict:      true\n",
+		"properties:  PartialOrdered|StrictOrdered|TotallyOrdered|Invertible|Countable|Lattice|Discrete|TorsionFree\nrank:        1\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain\n%s\nbut got\n%s", want, got)
+		}
+	}
+}
+
+func TestREPLRank(t *testing.T) {
+	q, q2, z := set.NewPosRat(0), set.NewPosRat(2), set.NewIntTuple(1)
+	for _, tc := range []struct {
+		g    abelian.Group
+		want int
+	}{
+		{abelian.New(q2, q2.Mul), 2},
+		{abelian.New(q, q.Mul), prop.InfiniteRank},
+		{abelian.Product(abelian.New(z, z.Add), abelian.New(q2, q2.Mul)), 3},
+		{abelian.Product(abelian.New(z, z.Add), abelian.New(q, q.Mul)), prop.InfiniteRank},
+	} {
+		if got, ok := rank(tc.g); !ok || tc.want != got {
+			t.Errorf("expected rank of %s to be %d but got %d (ok=%t)", tc.g, tc.want, got, ok)
+		}
+	}
+}
+
+func TestREPLHistory(t *testing.T) {
+	got := session(t, "1 + 1", "2 + 2", "!1", "!!", "history", "!9", "quit", "3 + 3")
+	want := `2
+4
+1 + 1
+2
+1 + 1
+2
+   1  1 + 1
+   2  2 + 2
+   3  1 + 1
+   4  1 + 1
+   5  history
+error: no history entry 9
+`
+	if want != got {
+		t.Errorf("expected output\n%s\nbut got\n%s", want, got)
+	}
+}
+
+func TestREPLComplete(t *testing.T) {
+	r := newREPL(ioutil.Discard)
+	r.exec("let group2 = 1")
+	if want, got := "[group group2 groups]", strings.Join(r.complete("gr"), " "); "["+got+"]" != want {
+		t.Errorf("expected completions %s but got [%s]", want, got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nickng/abelian/lattice"
+	"github.com/nickng/abelian/set"
+)
+
+// snfCmd writes the Smith normal form of a relation matrix,
+// with rows from args or from r if there are no args.
+func snfCmd(args []string, r io.Reader, w io.Writer) error {
+	if len(args) == 0 {
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			if line := strings.TrimSpace(sc.Text()); line != "" {
+				args = append(args, line)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return err
+		}
+	}
+	if len(args) == 0 {
+		return errUsage
+	}
+	rows := make([]set.IntTuple, len(args))
+	for i, arg := range args {
+		row, err := set.ParseIntTuple(arg)
+		if err != nil {
+			return err
+		}
+		if i > 0 && row.Size() != rows[0].Size() {
+			return fmt.Errorf("cannot use row %s: %v", row, set.MismatchDimErr{Dim1: row.Size(), Dim2: rows[0].Size()})
+		}
+		rows[i] = row
+	}
+	d := lattice.SmithNormalForm(rows)
+	factors := make([]string, len(d))
+	for i, v := range d {
+		factors[i] = fmt.Sprint(v)
+	}
+	if _, err := fmt.Fprintf(w, "invariant factors: %s\n", strings.Join(factors, " ")); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "quotient: %s\n", quotient(d, rows[0].Size()))
+	return err
+}
+
+// quotient returns the name of ℤⁿ/〈rows〉 from the invariant factors d
+// of the relation matrix, e.g. ℤ/2xℤ/6xℤ.
+func quotient(d []int, n int) string {
+	var names []string
+	for _, v := range d {
+		switch v {
+		case 0:
+			names = append(names, "ℤ")
+		case 1: // trivial.
+		default:
+			names = append(names, fmt.Sprintf("ℤ/%d", v))
+		}
+	}
+	for i := len(d); i < n; i++ {
+		names = append(names, "ℤ")
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "x")
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSNF(t *testing.T) {
+	want := "invariant factors: 2 6 12\nquotient: ℤ/2xℤ/6xℤ/12\n"
+	if got := mustRun(t, "snf", "(2,4,4)", "(-6,6,12)", "(10,-4,-16)"); want != got {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+	got, err := runCmd(t, "(2,4,4)\n\n(-6,6,12)\n(10,-4,-16)\n", "snf")
+	if err != nil {
+		t.Fatalf("cannot run snf from stdin: %v", err)
+	}
+	if want != got {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+}
+
+func TestQuotient(t *testing.T) {
+	tests := []struct {
+		d    []int
+		n    int
+		want string
+	}{
+		{[]int{1, 1}, 2, "0"},
+		{[]int{1, 0}, 2, "ℤ"},
+		{[]int{2}, 3, "ℤ/2xℤxℤ"},
+	}
+	for _, test := range tests {
+		if want, got := test.want, quotient(test.d, test.n); want != got {
+			t.Errorf("expected quotient %v in ℤ^%d to be %s but got %s", test.d, test.n, want, got)
+		}
+	}
+}
@@ -22,7 +22,7 @@ func ExampleNew_integer() {
 	fmt.Println("Group:", g.String())
 	fmt.Println(output)
 	// Output:
-	// Group: 〈ℤ, set.BinOp, 0〉
+	// Group: 〈ℤ, +, 0〉
 	// 3
 }
 
@@ -41,7 +41,7 @@ func ExampleNew_pair() {
 	fmt.Println("Group:", g.String())
 	fmt.Println(output)
 	// Output:
-	// Group: 〈ℤxℤ, set.BinOp, (0,0)〉
+	// Group: 〈ℤxℤ, +, (0,0)〉
 	// (3,5)
 }
 
@@ -60,7 +60,7 @@ func ExampleNew_triple() {
 	fmt.Println("Group:", g.String())
 	fmt.Println(output)
 	// Output:
-	// Group: 〈ℤxℤxℤ, set.BinOp, (0,0,0)〉
+	// Group: 〈ℤxℤxℤ, +, (0,0,0)〉
 	// (4,6,8)
 }
 
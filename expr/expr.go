@@ -0,0 +1,278 @@
+// Package expr implements a small expression language
+// for the elements of an abelian group.
+//
+// An expression combines elements with the group operation + and its
+// inverse -, for example
+//
+//	(1,2) + 3*(0,1) - (4,4)
+//
+// The language has
+//
+//	literals     (1,2) or 3, parsed by the Set of the group (set.ElemParser)
+//	variables    x, bound by an Env
+//	operations   x + y, x - y, -x and n*x for an integer n
+//	grouping     (x + y)
+//	sums         sum(x in (0,0)..(1,1), 2*x), the sum of 2*x over an interval
+//
+// Subtraction and negation require the Set of the group to implement
+// prop.Invertible, and sums require prop.PartialOrdered.
+//
+//	s := set.NewIntTuple(2)
+//	x, err := expr.Eval(abelian.New(s, s.Add), "(1,2) + 3*(0,1) - (4,4)", nil)
+//	// x is (-3,1)
+package expr
+
+import (
+	"fmt"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// Env binds variable names to Elems.
+type Env map[string]set.Elem
+
+// Expr is a parsed expression.
+type Expr interface {
+	// Eval evaluates the expression in the group g
+	// with the variables bound by env.
+	Eval(g abelian.Group, env Env) (set.Elem, error)
+
+	// Pos returns the byte offset of the expression in the parsed text.
+	Pos() int
+
+	String() string
+}
+
+// Error is the type of error where an expression cannot be evaluated.
+type Error struct {
+	Text string // Text is the expression being evaluated.
+	Pos  int    // Pos is the byte offset of the error in Text.
+	Err  error
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("cannot evaluate %q at position %d: %v", e.Text, e.Pos, e.Err)
+}
+
+// Eval parses and evaluates text in the group g
+// with the variables bound by env.
+func Eval(g abelian.Group, text string, env Env) (set.Elem, error) {
+	e, err := Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return e.Eval(g, env)
+}
+
+// node is the position of an expression in the parsed text.
+type node struct {
+	text string // text is the whole parsed text.
+	pos  int
+}
+
+func (n node) Pos() int {
+	return n.pos
+}
+
+func (n node) errorf(format string, args ...interface{}) error {
+	return Error{Text: n.text, Pos: n.pos, Err: fmt.Errorf(format, args...)}
+}
+
+func (n node) error(err error) error {
+	if _, ok := err.(Error); ok {
+		return err
+	}
+	return Error{Text: n.text, Pos: n.pos, Err: err}
+}
+
+// Literal is an Elem written as text, e.g. (1,2).
+type Literal struct {
+	node
+	Elem string
+}
+
+// Eval parses the literal with the Set of g.
+func (l Literal) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	x, err := set.ParseElem(g.Set, l.Elem)
+	if err != nil {
+		if perr, ok := err.(set.ParseError); ok {
+			perr.Text, perr.Pos = l.text, l.pos+perr.Pos
+			return nil, perr
+		}
+		return nil, l.error(err)
+	}
+	return x, nil
+}
+
+func (l Literal) String() string {
+	return l.Elem
+}
+
+// Var is a variable.
+type Var struct {
+	node
+	Name string
+}
+
+// Eval returns the Elem bound to the variable in env.
+func (v Var) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	x, ok := env[v.Name]
+	if !ok {
+		return nil, v.errorf("undefined variable %s", v.Name)
+	}
+	if !g.IsIn(x) {
+		return nil, v.errorf("variable %s = %v is not a member of %s", v.Name, x, g.Name())
+	}
+	return x, nil
+}
+
+func (v Var) String() string {
+	return v.Name
+}
+
+// Neg is the inverse -X.
+type Neg struct {
+	node
+	X Expr
+}
+
+// Eval returns the inverse of X.
+func (n Neg) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	x, err := n.X.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	inv, ok := g.Set.(prop.Invertible)
+	if !ok {
+		return nil, n.error(abelian.ErrNotInvertible)
+	}
+	return inv.Inverse(x), nil
+}
+
+func (n Neg) String() string {
+	return "-" + n.X.String()
+}
+
+// Scale is the scalar multiple N*X, i.e. X added to itself N times.
+type Scale struct {
+	node
+	N int
+	X Expr
+}
+
+// Eval returns N*X, where a negative N requires X to be invertible.
+func (s Scale) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	x, err := s.X.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	n := s.N
+	if n < 0 {
+		inv, ok := g.Set.(prop.Invertible)
+		if !ok {
+			return nil, s.error(abelian.ErrNotInvertible)
+		}
+		x, n = inv.Inverse(x), -n
+	}
+	// Double and add.
+	sum := g.Identity()
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			sum = g.Op(sum, x)
+		}
+		if n > 1 {
+			x = g.Op(x, x)
+		}
+	}
+	return sum, nil
+}
+
+func (s Scale) String() string {
+	return fmt.Sprintf("%d*%s", s.N, s.X)
+}
+
+// Binary is X + Y or X - Y.
+type Binary struct {
+	node
+	Op   byte // Op is '+' or '-'.
+	X, Y Expr
+}
+
+// Eval returns X + Y or X - Y under the operation of g.
+func (b Binary) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	x, err := b.X.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	y, err := b.Y.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	if b.Op == '+' {
+		return g.Op(x, y), nil
+	}
+	z, err := g.Sub(x, y)
+	if err != nil {
+		return nil, b.error(err)
+	}
+	return z, nil
+}
+
+func (b Binary) String() string {
+	return fmt.Sprintf("(%s %c %s)", b.X, b.Op, b.Y)
+}
+
+// Sum is the sum of Body over the interval Lo..Hi,
+// with each Elem of the interval bound to Var.
+type Sum struct {
+	node
+	Var    string
+	Lo, Hi Expr
+	Body   Expr
+}
+
+// Eval returns the sum of Body over the interval.
+func (s Sum) Eval(g abelian.Group, env Env) (set.Elem, error) {
+	ord, ok := g.Set.(prop.PartialOrdered)
+	if !ok {
+		return nil, s.errorf("cannot sum over intervals of %s: set is not ordered", g.Name())
+	}
+	lo, err := s.Lo.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := s.Hi.Eval(g, env)
+	if err != nil {
+		return nil, err
+	}
+	sum := g.Identity()
+	iv := ord.Interval(lo, hi)
+	if c, ok := iv.(interface{ Cardinality() int }); ok && c.Cardinality() == 0 {
+		return sum, nil
+	}
+	local := make(Env, len(env)+1)
+	for name, x := range env {
+		local[name] = x
+	}
+	it := iv.Enumerate()
+	for {
+		next, more := it.Next()
+		if next != nil {
+			local[s.Var] = next
+			x, err := s.Body.Eval(g, local)
+			if err != nil {
+				return nil, err
+			}
+			sum = g.Op(sum, x)
+		}
+		if !more {
+			return sum, nil
+		}
+	}
+}
+
+func (s Sum) String() string {
+	return fmt.Sprintf("sum(%s in %s..%s, %s)", s.Var, s.Lo, s.Hi, s.Body)
+}
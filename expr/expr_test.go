@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/internal/settest"
+	"github.com/nickng/abelian/set"
+)
+
+func TestEval(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	env := Env{"x": s.Tuple(1, 1), "y": s.Tuple(0, -2)}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"(1,2) + 3*(0,1) - (4,4)", "(-3,1)"},
+		{"(- 3,2)", "(-3,2)"},
+		{"(+ 1, -\n2)", "(1,-2)"},
+		{"-(1,2)", "(-1,-2)"},
+		{"x - -y", "(1,-1)"},
+		{"2*(x + y) - x", "(1,-3)"},
+		{"0*x", "(0,0)"},
+		{"5*x", "(5,5)"},
+		{"-3*x", "(-3,-3)"},
+		{"sum(v in (0,0)..(1,2), v)", "(3,6)"},
+		{"sum(v in x..(2,1), 2*v + y)", "(6,0)"},
+		{"sum(v in (1,0)..(0,0), v)", "(0,0)"},
+		{"sum(v in (0,0)..(0,1), sum(w in (0,0)..v, w))", "(0,1)"},
+	}
+	for _, test := range tests {
+		x, err := Eval(g, test.text, env)
+		if err != nil {
+			t.Errorf("cannot evaluate %q: %v", test.text, err)
+			continue
+		}
+		if want, got := test.want, x.String(); want != got {
+			t.Errorf("expected %q to evaluate to %s but got %s", test.text, want, got)
+		}
+	}
+	if _, ok := env["v"]; ok {
+		t.Errorf("sum should not bind its variable in the environment")
+	}
+}
+
+func TestEvalError(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	tests := []struct {
+		text string
+		pos  int
+	}{
+		{"(1,2) + z", 8},
+		{"(1,2) + (1,2,3)", 8},
+		{"(1,2) + 3", 8},
+		{"(1,2) + (1,99999999999999999999)", 11},
+	}
+	for _, test := range tests {
+		_, err := Eval(g, test.text, nil)
+		if err == nil {
+			t.Errorf("expected error evaluating %q", test.text)
+			continue
+		}
+		var pos int
+		switch err := err.(type) {
+		case Error:
+			pos = err.Pos
+		case set.ParseError:
+			pos = err.Pos
+		default:
+			t.Errorf("expected error with position evaluating %q but got %v", test.text, err)
+			continue
+		}
+		if want, got := test.pos, pos; want != got {
+			t.Errorf("expected error evaluating %q at %d but got %d: %v", test.text, want, got, err)
+		}
+	}
+
+	n := settest.NonInvertible{IntTupleSet: s}
+	_, err := Eval(abelian.New(n, n.Add), "(1,2) - (0,1)", nil)
+	if e, ok := err.(Error); !ok || e.Err != abelian.ErrNotInvertible || e.Pos != 6 {
+		t.Errorf("expected %v at position 6 but got %v", abelian.ErrNotInvertible, err)
+	}
+}
@@ -0,0 +1,287 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nickng/abelian/set"
+)
+
+// Parse parses text into an expression.
+//
+// Syntax errors are returned as set.ParseError
+// with the position of the error in text.
+func Parse(text string) (Expr, error) {
+	toks, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{text: text, toks: toks}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tEOF {
+		return nil, p.errorf(t, "unexpected %s after expression", t)
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tInt
+	tIdent
+	tPunct // ( ) , + - * ..
+)
+
+type token struct {
+	kind     tokenKind
+	val      string
+	pos, end int
+}
+
+func (t token) String() string {
+	if t.kind == tEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.val)
+}
+
+func (t token) is(punct string) bool {
+	return t.kind == tPunct && t.val == punct
+}
+
+// lex splits text into tokens, ending with a tEOF token.
+func lex(text string) ([]token, error) {
+	var toks []token
+	for pos := 0; pos < len(text); {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		start := pos
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			pos += size
+			continue
+		case '0' <= r && r <= '9':
+			for pos < len(text) && '0' <= text[pos] && text[pos] <= '9' {
+				pos++
+			}
+			toks = append(toks, token{kind: tInt, val: text[start:pos], pos: start, end: pos})
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for pos < len(text) {
+				r, size := utf8.DecodeRuneInString(text[pos:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				pos += size
+			}
+			toks = append(toks, token{kind: tIdent, val: text[start:pos], pos: start, end: pos})
+			continue
+		case r == '.' && pos+1 < len(text) && text[pos+1] == '.':
+			pos += 2
+		case r == '(' || r == ')' || r == ',' || r == '+' || r == '-' || r == '*':
+			pos++
+		default:
+			return nil, set.ParseError{Text: text, Pos: pos, Msg: fmt.Sprintf("unexpected %s", strconv.QuoteRune(r))}
+		}
+		toks = append(toks, token{kind: tPunct, val: text[start:pos], pos: start, end: pos})
+	}
+	return append(toks, token{kind: tEOF, pos: len(text), end: len(text)}), nil
+}
+
+// parser is a recursive descent parser of the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = "-" term | int "*" term | primary
+//	primary = literal | ident | "(" expr ")" | sum
+//	literal = int | tuple
+//	tuple   = "(" item { "," item } ")"
+//	item    = ["-" | "+"] int | tuple
+//	sum     = "sum" "(" ident "in" expr ".." expr "," expr ")"
+type parser struct {
+	text string
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.i+n < len(p.toks) {
+		return p.toks[p.i+n]
+	}
+	return p.toks[len(p.toks)-1]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return set.ParseError{Text: p.text, Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(punct string) (token, error) {
+	t := p.next()
+	if !t.is(punct) {
+		return t, p.errorf(t, "expecting %q but got %s", punct, t)
+	}
+	return t, nil
+}
+
+func (p *parser) node(t token) node {
+	return node{text: p.text, pos: t.pos}
+}
+
+func (p *parser) expr() (Expr, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.is("+") || t.is("-"); t = p.peek() {
+		p.next()
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = Binary{node: p.node(t), Op: t.val[0], X: x, Y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	t := p.peek()
+	switch {
+	case t.is("-"):
+		p.next()
+		x, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return Neg{node: p.node(t), X: x}, nil
+	case t.kind == tInt && p.peekAt(1).is("*"):
+		n, err := strconv.Atoi(t.val)
+		if err != nil {
+			return nil, p.errorf(t, "invalid integer %q: %v", t.val, err.(*strconv.NumError).Err)
+		}
+		p.next()
+		p.next()
+		x, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return Scale{node: p.node(t), N: n, X: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Expr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tInt:
+		p.next()
+		return Literal{node: p.node(t), Elem: t.val}, nil
+	case t.kind == tIdent && t.val == "sum" && p.peekAt(1).is("("):
+		return p.sum()
+	case t.kind == tIdent:
+		p.next()
+		return Var{node: p.node(t), Name: t.val}, nil
+	case t.is("("):
+		if end, ok := p.literal(); ok {
+			p.i = end + 1
+			return Literal{node: p.node(t), Elem: p.text[t.pos:p.toks[end].end]}, nil
+		}
+		p.next()
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.errorf(t, "expecting expression but got %s", t)
+}
+
+// literal returns the index of the closing ")" if the tokens from "("
+// are a tuple literal, whose components may be tuples, e.g. ((1,2),3).
+func (p *parser) literal() (end int, ok bool) {
+	return p.tuple(p.i, true)
+}
+
+// tuple returns the index of the closing ")" if the tokens from "(" at i
+// are a tuple. Only the outermost tuple can have a single component, an
+// integer, so that ((1,2)) is the tuple (1,2) in grouping parentheses.
+func (p *parser) tuple(i int, outer bool) (end int, ok bool) {
+	for n := 0; ; n++ {
+		i++
+		single := outer && n == 0
+		switch {
+		case (p.toks[i].is("-") || p.toks[i].is("+")) && p.toks[i+1].kind == tInt:
+			i++
+		case p.toks[i].kind == tInt:
+		case p.toks[i].is("("):
+			if i, ok = p.tuple(i, false); !ok {
+				return 0, false
+			}
+			single = false
+		default:
+			return 0, false
+		}
+		i++
+		switch {
+		case p.toks[i].is(")"):
+			if n == 0 && !single {
+				return 0, false
+			}
+			return i, true
+		case !p.toks[i].is(","):
+			return 0, false
+		}
+	}
+}
+
+func (p *parser) sum() (Expr, error) {
+	t := p.next() // sum
+	p.next()      // (
+	v := p.next()
+	if v.kind != tIdent {
+		return nil, p.errorf(v, "expecting variable but got %s", v)
+	}
+	if in := p.next(); in.kind != tIdent || in.val != "in" {
+		return nil, p.errorf(in, "expecting \"in\" but got %s", in)
+	}
+	lo, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(".."); err != nil {
+		return nil, err
+	}
+	hi, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(","); err != nil {
+		return nil, err
+	}
+	body, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return Sum{node: p.node(t), Var: v.val, Lo: lo, Hi: hi, Body: body}, nil
+}
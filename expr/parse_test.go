@@ -0,0 +1,64 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"(1,2) + 3*(0,1) - (4,4)", "(((1,2) + 3*(0,1)) - (4,4))"},
+		{"(1, -2)", "(1, -2)"},
+		{"(x)", "x"},
+		{"(3)", "(3)"},
+		{"-2*x", "-2*x"},
+		{"x - (y + z)", "(x - (y + z))"},
+		{"sum(v in 0..10, 2*v)", "sum(v in 0..10, 2*v)"},
+		{"sum + α1", "(sum + α1)"},
+		{"((1,2),-3) + ((0,0),1)", "(((1,2),-3) + ((0,0),1))"},
+		{"((1,2))", "(1,2)"},
+	}
+	for _, test := range tests {
+		e, err := Parse(test.text)
+		if err != nil {
+			t.Errorf("cannot parse %q: %v", test.text, err)
+			continue
+		}
+		if want, got := test.want, e.String(); want != got {
+			t.Errorf("expected %q to parse as %s but got %s", test.text, want, got)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		text string
+		pos  int
+	}{
+		{"", 0},
+		{"(1,2) +", 7},
+		{"(1,2) $ (3,4)", 6},
+		{"(1,2) (3,4)", 6},
+		{"(x + 1", 6},
+		{"sum(1 in 0..1, x)", 4},
+		{"sum(v on 0..1, v)", 6},
+		{"sum(v in 0, v)", 10},
+		{"2*", 2},
+		{"((1,2),x)", 6},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.text)
+		perr, ok := err.(set.ParseError)
+		if !ok {
+			t.Errorf("expected parse error for %q but got %v", test.text, err)
+			continue
+		}
+		if want, got := test.pos, perr.Pos; want != got {
+			t.Errorf("expected parse error for %q at %d but got %d: %v", test.text, want, got, err)
+		}
+	}
+}
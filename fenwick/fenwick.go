@@ -0,0 +1,181 @@
+// Package fenwick implements multi-dimensional Fenwick (binary indexed)
+// trees whose values are elements of an abelian group.
+//
+// A Tree supports point updates and range queries over an
+// IntTupleInterval domain in O(logⁿ N) group operations, for any
+// abelian.Group whose Set implements prop.Invertible:
+//
+//	s := set.NewIntTuple(2)
+//	domain := s.Interval(s.Tuple(0, 0), s.Tuple(9, 9)).(set.IntTupleInterval)
+//	v := set.NewIntTuple(1)
+//	t, _ := fenwick.New(abelian.New(v, v.Add), domain)
+//	t.Update(s.Tuple(3, 4), v.Tuple(5))
+//	t.Query(s.Interval(s.Tuple(0, 0), s.Tuple(5, 5)).(set.IntTupleInterval)) // 5
+package fenwick
+
+import (
+	"log"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// Tree is a Fenwick tree over the box of an IntTupleInterval.
+type Tree struct {
+	g       abelian.Group
+	lo, hi  set.IntTuple
+	size    []int // size[d] is the number of positions in dimension d.
+	strides []int
+	nodes   []set.Elem
+}
+
+// New returns a Fenwick tree over the box of domain,
+// with the identity of g at every position.
+//
+// The Set of g must implement prop.Invertible for range queries,
+// otherwise abelian.ErrNotInvertible is returned.
+func New(g abelian.Group, domain set.IntTupleInterval) (*Tree, error) {
+	if _, ok := g.Set.(prop.Invertible); !ok {
+		return nil, abelian.ErrNotInvertible
+	}
+	lo, hi := domain.Lo(), domain.Hi()
+	t := &Tree{
+		g:       g,
+		lo:      lo,
+		hi:      hi,
+		size:    make([]int, lo.Size()),
+		strides: make([]int, lo.Size()),
+	}
+	total := 1
+	for d := lo.Size() - 1; d >= 0; d-- {
+		if hi[d] < lo[d] {
+			log.Fatalf("cannot create Fenwick tree over %s: empty dimension %d", domain.Name(), d)
+		}
+		t.size[d] = hi[d] - lo[d] + 1
+		t.strides[d] = total
+		total *= t.size[d]
+	}
+	t.nodes = make([]set.Elem, total)
+	for i := range t.nodes {
+		t.nodes[i] = g.Identity()
+	}
+	return t, nil
+}
+
+// Group returns the group of the values of t.
+func (t *Tree) Group() abelian.Group {
+	return t.g
+}
+
+// Domain returns the box of positions of t.
+func (t *Tree) Domain() set.IntTupleInterval {
+	return set.NewIntTuple(t.lo.Size()).Interval(t.lo, t.hi).(set.IntTupleInterval)
+}
+
+// Update adds the value v to the position x, i.e. value(x) = value(x)·v.
+func (t *Tree) Update(x, v set.Elem) {
+	pos := t.position(x)
+	t.update(0, 0, pos, v)
+}
+
+func (t *Tree) update(d, offset int, pos []int, v set.Elem) {
+	if d == len(pos) {
+		t.nodes[offset] = t.g.Op(t.nodes[offset], v)
+		return
+	}
+	for i := pos[d]; i <= t.size[d]; i += i & -i {
+		t.update(d+1, offset+(i-1)*t.strides[d], pos, v)
+	}
+}
+
+// Prefix returns the sum of the values at the positions in the box lo..x.
+func (t *Tree) Prefix(x set.Elem) set.Elem {
+	return t.prefix(0, 0, t.position(x))
+}
+
+func (t *Tree) prefix(d, offset int, pos []int) set.Elem {
+	if d == len(pos) {
+		return t.nodes[offset]
+	}
+	sum := t.g.Identity()
+	for i := pos[d]; i > 0; i -= i & -i {
+		sum = t.g.Op(sum, t.prefix(d+1, offset+(i-1)*t.strides[d], pos))
+	}
+	return sum
+}
+
+// Query returns the sum of the values at the positions in the box of iv,
+// which is clipped to the domain of t. The sum over an empty box, or
+// a box outside the domain, is the identity.
+//
+// The sum is computed by inclusion–exclusion over the 2ⁿ corners of the
+// box, where the excluded prefixes are subtracted with the group inverse.
+func (t *Tree) Query(iv set.IntTupleInterval) set.Elem {
+	ivLo, ivHi := iv.Lo(), iv.Hi()
+	if ivLo.Size() != t.lo.Size() {
+		log.Fatal(set.MismatchDimErr{Dim1: ivLo.Size(), Dim2: t.lo.Size()})
+	}
+	lo, hi := make([]int, len(ivLo)), make([]int, len(ivHi))
+	for d := range lo {
+		l, h := ivLo[d], ivHi[d]
+		if l < t.lo[d] {
+			l = t.lo[d]
+		}
+		if h > t.hi[d] {
+			h = t.hi[d]
+		}
+		if l > h {
+			return t.g.Identity()
+		}
+		lo[d], hi[d] = l-t.lo[d]+1, h-t.lo[d]+1
+	}
+	plus, minus := t.g.Identity(), t.g.Identity()
+	corner := make([]int, len(lo))
+	for mask := 0; mask < 1<<uint(len(lo)); mask++ {
+		odd, empty := false, false
+		for d := range corner {
+			if mask&(1<<uint(d)) != 0 {
+				corner[d] = lo[d] - 1
+				odd = !odd
+			} else {
+				corner[d] = hi[d]
+			}
+			if corner[d] == 0 {
+				empty = true
+			}
+		}
+		if empty {
+			continue
+		}
+		if odd {
+			minus = t.g.Op(minus, t.prefix(0, 0, corner))
+		} else {
+			plus = t.g.Op(plus, t.prefix(0, 0, corner))
+		}
+	}
+	sum, _ := t.g.Sub(plus, minus)
+	return sum
+}
+
+// Get returns the value at the position x.
+func (t *Tree) Get(x set.Elem) set.Elem {
+	xElem := x.(set.IntTuple)
+	return t.Query(set.NewIntTuple(xElem.Size()).Interval(xElem, xElem).(set.IntTupleInterval))
+}
+
+// position returns the 1-based position of x in each dimension.
+func (t *Tree) position(x set.Elem) []int {
+	xElem := x.(set.IntTuple)
+	if xElem.Size() != t.lo.Size() {
+		log.Fatal(set.MismatchDimErr{Dim1: xElem.Size(), Dim2: t.lo.Size()})
+	}
+	pos := make([]int, xElem.Size())
+	for d := range pos {
+		if xElem[d] < t.lo[d] || xElem[d] > t.hi[d] {
+			log.Fatalf("cannot find %s in Fenwick tree: outside domain %s≤..≤%s", xElem, t.lo, t.hi)
+		}
+		pos[d] = xElem[d] - t.lo[d] + 1
+	}
+	return pos
+}
@@ -0,0 +1,124 @@
+package fenwick
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/internal/settest"
+	"github.com/nickng/abelian/set"
+)
+
+func TestTree2D(t *testing.T) {
+	s := set.NewIntTuple(2)
+	v := set.NewIntTuple(1)
+	domain := s.Interval(s.Tuple(-2, 1), s.Tuple(5, 6)).(set.IntTupleInterval)
+	tree, err := New(abelian.New(v, v.Add), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	ref := make(map[[2]int]int)
+	for i := 0; i < 200; i++ {
+		x := s.Tuple(rnd.Intn(8)-2, rnd.Intn(6)+1)
+		d := rnd.Intn(21) - 10
+		tree.Update(x, v.Tuple(d))
+		ref[[2]int{x[0], x[1]}] += d
+	}
+	for i := 0; i < 100; i++ {
+		a := s.Tuple(rnd.Intn(8)-2, rnd.Intn(6)+1)
+		b := s.Tuple(rnd.Intn(8)-2, rnd.Intn(6)+1)
+		lo, hi := a.Clone(), b.Clone()
+		for d := range lo {
+			if lo[d] > hi[d] {
+				lo[d], hi[d] = hi[d], lo[d]
+			}
+		}
+		want := 0
+		for k, val := range ref {
+			if lo[0] <= k[0] && k[0] <= hi[0] && lo[1] <= k[1] && k[1] <= hi[1] {
+				want += val
+			}
+		}
+		iv := s.Interval(lo, hi).(set.IntTupleInterval)
+		if got := tree.Query(iv); v.Tuple(want).Compare(got) != 0 {
+			t.Errorf("Query(%s): expected %d but got %s", iv.Name(), want, got)
+		}
+	}
+	if want, got := v.Tuple(ref[[2]int{0, 3}]), tree.Get(s.Tuple(0, 3)); want.Compare(got) != 0 {
+		t.Errorf("Get((0,3)): expected %s but got %s", want, got)
+	}
+}
+
+func TestTree3DTupleValues(t *testing.T) {
+	s := set.NewIntTuple(3)
+	v := set.NewIntTuple(2)
+	domain := s.Interval(s.Tuple(0, 0, 0), s.Tuple(3, 3, 3)).(set.IntTupleInterval)
+	tree, err := New(abelian.New(v, v.Add), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range domain.Slice() {
+		tree.Update(x, v.Tuple(1, x.(set.IntTuple)[0]))
+	}
+	got := tree.Query(s.Interval(s.Tuple(1, 1, 1), s.Tuple(2, 3, 3)).(set.IntTupleInterval))
+	if want := v.Tuple(18, 27); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := v.Tuple(64, 96), tree.Prefix(s.Tuple(3, 3, 3)); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestTreeFreeAbelian(t *testing.T) {
+	s := set.NewIntTuple(1)
+	f := set.NewFreeAbelian()
+	domain := s.Interval(s.Tuple(0), s.Tuple(9)).(set.IntTupleInterval)
+	tree, err := New(abelian.New(f, f.Add), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Update(s.Tuple(2), f.Basis("a"))
+	tree.Update(s.Tuple(5), f.Basis("b"))
+	tree.Update(s.Tuple(7), f.Basis("a"))
+	if want, got := "a + b", tree.Query(s.Interval(s.Tuple(1), s.Tuple(6)).(set.IntTupleInterval)).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestTreeQueryEmptyAndClipped(t *testing.T) {
+	s := set.NewIntTuple(2)
+	v := set.NewIntTuple(1)
+	domain := s.Interval(s.Tuple(0, 0), s.Tuple(3, 3)).(set.IntTupleInterval)
+	tree, err := New(abelian.New(v, v.Add), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range domain.Slice() {
+		tree.Update(x, v.Tuple(1))
+	}
+	for _, tc := range []struct {
+		lo, hi set.IntTuple
+		want   int
+	}{
+		{s.Tuple(2, 1), s.Tuple(1, 3), 0},    // empty in dimension 0.
+		{s.Tuple(0, 3), s.Tuple(3, 2), 0},    // empty in dimension 1.
+		{s.Tuple(3, 3), s.Tuple(0, 0), 0},    // empty in both dimensions.
+		{s.Tuple(-5, 2), s.Tuple(1, 9), 4},   // clipped to (0,2)..(1,3).
+		{s.Tuple(-5, -5), s.Tuple(9, 9), 16}, // clipped to the domain.
+		{s.Tuple(4, 0), s.Tuple(9, 3), 0},    // outside the domain.
+	} {
+		iv := s.Interval(tc.lo, tc.hi).(set.IntTupleInterval)
+		if got := tree.Query(iv); v.Tuple(tc.want).Compare(got) != 0 {
+			t.Errorf("Query(%s): expected %d but got %s", iv.Name(), tc.want, got)
+		}
+	}
+}
+
+func TestTreeNotInvertible(t *testing.T) {
+	s := set.NewIntTuple(1)
+	domain := s.Interval(s.Tuple(0), s.Tuple(9)).(set.IntTupleInterval)
+	if _, err := New(abelian.New(settest.NonInvertible{IntTupleSet: s}, s.Add), domain); err != abelian.ErrNotInvertible {
+		t.Errorf("expected %v but got %v", abelian.ErrNotInvertible, err)
+	}
+}
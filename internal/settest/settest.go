@@ -0,0 +1,12 @@
+// Package settest implements sets for testing the packages of abelian.
+package settest
+
+import "github.com/nickng/abelian/set"
+
+// NonInvertible is a set of integer tuples which does not implement
+// prop.Invertible, for testing operations which need inverses.
+type NonInvertible struct{ set.IntTupleSet }
+
+// Inverse hides set.IntTupleSet.Inverse with a method
+// whose signature does not match prop.Invertible.
+func (NonInvertible) Inverse() {}
@@ -0,0 +1,198 @@
+package lattice
+
+import (
+	"errors"
+	"log"
+	"math"
+	"math/big"
+
+	"github.com/nickng/abelian/set"
+)
+
+// ErrZeroLattice is the error returned when searching for
+// a nonzero vector in the zero lattice {0}.
+var ErrZeroLattice = errors.New("lattice: zero lattice has no nonzero vector")
+
+// ShortestVector returns a nonzero vector of the lattice
+// with the smallest Euclidean norm.
+//
+// The search enumerates all candidate vectors exactly (Fincke–Pohst)
+// over an LLL-reduced basis. Ties are broken in favour of the
+// lexicographically greatest vector, so the result is deterministic.
+func (l Lattice) ShortestVector() (set.IntTuple, error) {
+	if l.Rank() == 0 {
+		return nil, ErrZeroLattice
+	}
+	basis := LLL(l.basis, DefaultDelta)
+	e := newEnumerator(basis, nil)
+	e.best = basis[0]
+	e.bound = dotRat(ratVec(basis[0]), ratVec(basis[0]))
+	e.search(len(basis)-1, new(big.Rat))
+	return e.best, nil
+}
+
+// ClosestVector returns a vector of the lattice with the smallest
+// Euclidean distance to the IntTuple target.
+//
+// The target need not lie in the span of the lattice.
+// The search enumerates all candidate vectors exactly (Fincke–Pohst)
+// over an LLL-reduced basis, starting from Babai's nearest plane
+// solution. Ties are broken in favour of the lexicographically
+// greatest vector, so the result is deterministic.
+func (l Lattice) ClosestVector(target set.Elem) set.IntTuple {
+	t := target.(set.IntTuple)
+	if t.Size() != l.s.Size() {
+		log.Fatal(set.MismatchDimErr{Dim1: t.Size(), Dim2: l.s.Size()})
+	}
+	if l.Rank() == 0 {
+		return l.s.Identity().(set.IntTuple)
+	}
+	basis := LLL(l.basis, DefaultDelta)
+	e := newEnumerator(basis, ratVec(t))
+	e.best = e.babai()
+	e.bound = e.spanDist(e.best)
+	e.search(len(basis)-1, new(big.Rat))
+	return e.best
+}
+
+// enumerator searches for lattice vectors v = Σ x(i)·b(i) minimising
+// the distance to a target t within the span of the basis:
+//
+//	‖v - t‖² = Σ_j (x(j) + Σ_{i>j} μ(i,j)·x(i) - τ(j))²·‖b*(j)‖² + const
+//
+// where τ(j) is the coordinate of t along b*(j).
+type enumerator struct {
+	basis []set.IntTuple
+	g     *gso
+	tau   []*big.Rat // nil when searching for the shortest nonzero vector.
+	x     []int
+
+	best  set.IntTuple
+	bound *big.Rat // distance of best (without const).
+}
+
+func newEnumerator(basis []set.IntTuple, t []*big.Rat) *enumerator {
+	e := &enumerator{
+		basis: basis,
+		g:     newGSO(toBig(basis)),
+		x:     make([]int, len(basis)),
+	}
+	if t != nil {
+		e.tau = make([]*big.Rat, len(basis))
+		for j := range e.tau {
+			e.tau[j] = dotRat(t, e.g.star[j])
+			e.tau[j].Quo(e.tau[j], e.g.norm2[j])
+		}
+	}
+	return e
+}
+
+// center returns the real value of x(j) minimising the j-th term
+// given x(i) for i > j.
+func (e *enumerator) center(j int) *big.Rat {
+	c := new(big.Rat)
+	if e.tau != nil {
+		c.Set(e.tau[j])
+	}
+	for i := j + 1; i < len(e.x); i++ {
+		c.Sub(c, new(big.Rat).Mul(e.g.mu[i][j], big.NewRat(int64(e.x[i]), 1)))
+	}
+	return c
+}
+
+// term returns (x - c)²·‖b*(j)‖².
+func (e *enumerator) term(j, x int, c *big.Rat) *big.Rat {
+	d := new(big.Rat).Sub(big.NewRat(int64(x), 1), c)
+	d.Mul(d, d)
+	return d.Mul(d, e.g.norm2[j])
+}
+
+// search enumerates x(j), x(j-1), ..., x(0) given the partial distance
+// of the coordinates above j, updating best whenever a closer vector is found.
+func (e *enumerator) search(j int, partial *big.Rat) {
+	if j < 0 {
+		e.visit(partial)
+		return
+	}
+	c := e.center(j)
+	rem := new(big.Rat).Sub(e.bound, partial)
+	// Candidate range from floating point, widened and checked exactly.
+	cf, _ := c.Float64()
+	rf, _ := new(big.Rat).Quo(rem, e.g.norm2[j]).Float64()
+	r := math.Sqrt(math.Max(rf, 0))
+	lo, hi := int(math.Floor(cf-r))-1, int(math.Ceil(cf+r))+1
+	for x := lo; x <= hi; x++ {
+		d := e.term(j, x, c)
+		d.Add(d, partial)
+		if d.Cmp(e.bound) > 0 {
+			continue
+		}
+		e.x[j] = x
+		e.search(j-1, d)
+	}
+	e.x[j] = 0
+}
+
+// visit considers the vector with coefficients x at the given distance.
+func (e *enumerator) visit(dist *big.Rat) {
+	if e.tau == nil && e.isZero() {
+		return
+	}
+	v := e.vector()
+	if cmp := dist.Cmp(e.bound); cmp < 0 || (cmp == 0 && v.Compare(e.best) > 0) {
+		e.best, e.bound = v, new(big.Rat).Set(dist)
+	}
+}
+
+func (e *enumerator) isZero() bool {
+	for _, x := range e.x {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// vector returns Σ x(i)·b(i).
+func (e *enumerator) vector() set.IntTuple {
+	v := make(set.IntTuple, e.basis[0].Size())
+	for i, x := range e.x {
+		axpy(v, x, e.basis[i])
+	}
+	return v
+}
+
+// babai returns the lattice vector found by Babai's nearest plane algorithm.
+func (e *enumerator) babai() set.IntTuple {
+	for j := len(e.x) - 1; j >= 0; j-- {
+		e.x[j] = int(round(e.center(j)).Int64())
+	}
+	v := e.vector()
+	for j := range e.x {
+		e.x[j] = 0
+	}
+	return v
+}
+
+// spanDist returns the distance of the lattice vector v
+// to the target within the span of the basis.
+func (e *enumerator) spanDist(v set.IntTuple) *big.Rat {
+	dist := new(big.Rat)
+	vr := ratVec(v)
+	for j := range e.basis {
+		d := dotRat(vr, e.g.star[j])
+		d.Quo(d, e.g.norm2[j])
+		d.Sub(d, e.tau[j])
+		d.Mul(d, d)
+		dist.Add(dist, d.Mul(d, e.g.norm2[j]))
+	}
+	return dist
+}
+
+func ratVec(v set.IntTuple) []*big.Rat {
+	r := make([]*big.Rat, v.Size())
+	for i := range v {
+		r[i] = big.NewRat(int64(v[i]), 1)
+	}
+	return r
+}
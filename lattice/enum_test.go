@@ -0,0 +1,94 @@
+package lattice
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func norm2(v set.IntTuple) int {
+	return set.L2Sq.Of(v)
+}
+
+// bruteClosest returns the distance of the closest lattice vector to t
+// (nonzero if nonzero is set) in the box [-r,r]ⁿ.
+func bruteClosest(l Lattice, t set.IntTuple, r int, nonzero bool) int {
+	s := l.Set()
+	lo, hi := make(set.IntTuple, s.Size()), make(set.IntTuple, s.Size())
+	for i := range lo {
+		lo[i], hi[i] = -r, r
+	}
+	best := -1
+	for _, v := range s.Interval(lo, hi).Slice() {
+		if !l.IsIn(v) || (nonzero && norm2(v.(set.IntTuple)) == 0) {
+			continue
+		}
+		if d := set.L2Sq.Dist(v, t); best < 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func TestShortestVector(t *testing.T) {
+	s := set.NewIntTuple(3)
+	lattices := []Lattice{
+		New(s, s.Tuple(1, 1, 1), s.Tuple(-1, 0, 2), s.Tuple(3, 5, 6)),
+		New(s, s.Tuple(7, 3, 0), s.Tuple(5, 11, 2), s.Tuple(1, 1, 9)),
+		New(s, s.Tuple(4, 6, 0), s.Tuple(6, 9, 1)),
+	}
+	for _, l := range lattices {
+		v, err := l.ShortestVector()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !l.IsIn(v) {
+			t.Errorf("shortest vector %s should be in %s", v, l.Name())
+		}
+		if want, got := bruteClosest(l, s.Identity().(set.IntTuple), 8, true), norm2(v); want != got {
+			t.Errorf("%s: expected shortest norm² %d but got %d (%s)", l.Name(), want, got, v)
+		}
+	}
+}
+
+func TestShortestVectorTie(t *testing.T) {
+	s := set.NewIntTuple(2)
+	l := New(s, s.Tuple(1, 0), s.Tuple(0, 1))
+	v, err := l.ShortestVector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := s.Tuple(1, 0); want.Compare(v) != 0 {
+		t.Errorf("expected %s but got %s", want, v)
+	}
+}
+
+func TestShortestVectorZero(t *testing.T) {
+	if _, err := New(set.NewIntTuple(2)).ShortestVector(); err != ErrZeroLattice {
+		t.Errorf("expected %v but got %v", ErrZeroLattice, err)
+	}
+}
+
+func TestClosestVector(t *testing.T) {
+	s := set.NewIntTuple(3)
+	l := New(s, s.Tuple(7, 3, 0), s.Tuple(5, 11, 2), s.Tuple(1, 1, 9))
+	targets := []set.IntTuple{s.Tuple(0, 0, 0), s.Tuple(3, 4, 5), s.Tuple(-6, 2, 7), s.Tuple(10, -3, 1)}
+	for _, target := range targets {
+		v := l.ClosestVector(target)
+		if !l.IsIn(v) {
+			t.Errorf("closest vector %s should be in %s", v, l.Name())
+		}
+		if want, got := bruteClosest(l, target, 20, false), set.L2Sq.Dist(v, target); want != got {
+			t.Errorf("%s: expected distance² %d to %s but got %d (%s)", l.Name(), want, target, got, v)
+		}
+	}
+}
+
+func TestClosestVectorOutsideSpan(t *testing.T) {
+	s := set.NewIntTuple(3)
+	l := New(s, s.Tuple(2, 0, 0), s.Tuple(0, 3, 0))
+	v := l.ClosestVector(s.Tuple(3, 4, 7))
+	if want := s.Tuple(4, 3, 0); want.Compare(v) != 0 {
+		t.Errorf("expected %s but got %s", want, v)
+	}
+}
@@ -0,0 +1,180 @@
+// Package lattice implements subgroups of ℤⁿ (integer lattices)
+// given by generators.
+//
+// A Lattice is itself a set.Set, so it can be used to create the
+// subgroup as an abelian group:
+//
+//	s := set.NewIntTuple(2)
+//	l := lattice.New(s, s.Tuple(2, 0), s.Tuple(1, 3))
+//	g := abelian.New(l, l.Add) // creates the subgroup 〈(2,0),(1,3)〉 of ℤxℤ
+//
+// The package also provides LLL basis reduction and exact shortest and
+// closest vector search, which are exponential in the rank of the lattice
+// and intended for small dimensions.
+package lattice
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/nickng/abelian/set"
+)
+
+// Lattice is the subgroup of ℤⁿ generated by a set of IntTuples.
+type Lattice struct {
+	s     set.IntTupleSet
+	basis []set.IntTuple
+	ech   []set.IntTuple // basis in row echelon form for membership tests.
+}
+
+// New returns the lattice generated by the IntTuples gens of the set s.
+//
+// The generators need not be linearly independent,
+// the lattice keeps a basis computed from the generators.
+func New(s set.IntTupleSet, gens ...set.IntTuple) Lattice {
+	rows := make([]set.IntTuple, len(gens))
+	for i, g := range gens {
+		if g.Size() != s.Size() {
+			log.Fatal(set.MismatchDimErr{Dim1: g.Size(), Dim2: s.Size()})
+		}
+		rows[i] = g.Clone()
+	}
+	ech := echelon(rows)
+	return Lattice{s: s, basis: clone(ech), ech: ech}
+}
+
+// echelon transforms the rows into a basis of the same lattice
+// in row echelon form with positive pivots, using integer row operations.
+func echelon(rows []set.IntTuple) []set.IntTuple {
+	if len(rows) == 0 {
+		return nil
+	}
+	r := 0 // next pivot row.
+	for col := 0; col < rows[0].Size() && r < len(rows); col++ {
+		for {
+			// Find the row with the smallest nonzero |entry| in col.
+			p := -1
+			for i := r; i < len(rows); i++ {
+				if rows[i][col] != 0 && (p < 0 || abs(rows[i][col]) < abs(rows[p][col])) {
+					p = i
+				}
+			}
+			if p < 0 {
+				break // column is zero below r.
+			}
+			rows[r], rows[p] = rows[p], rows[r]
+			done := true
+			for i := r + 1; i < len(rows); i++ {
+				if q := rows[i][col] / rows[r][col]; q != 0 {
+					axpy(rows[i], -q, rows[r])
+				}
+				if rows[i][col] != 0 {
+					done = false
+				}
+			}
+			if done {
+				if rows[r][col] < 0 {
+					axpy(rows[r], -2, rows[r])
+				}
+				r++
+				break
+			}
+		}
+	}
+	return rows[:r]
+}
+
+// axpy sets y to y + a·x.
+func axpy(y set.IntTuple, a int, x set.IntTuple) {
+	for i := range y {
+		y[i] += a * x[i]
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Set returns the ambient set ℤⁿ of the lattice.
+func (l Lattice) Set() set.IntTupleSet {
+	return l.s
+}
+
+// Rank returns the rank of the lattice, i.e. the size of its basis.
+func (l Lattice) Rank() int {
+	return len(l.basis)
+}
+
+// Basis returns a basis of the lattice.
+func (l Lattice) Basis() []set.IntTuple {
+	return clone(l.basis)
+}
+
+func clone(vs []set.IntTuple) []set.IntTuple {
+	c := make([]set.IntTuple, len(vs))
+	for i := range vs {
+		c[i] = vs[i].Clone()
+	}
+	return c
+}
+
+// IsIn returns true if x is in the lattice, i.e. x is
+// an integer combination of the generators.
+func (l Lattice) IsIn(x set.Elem) bool {
+	xElem, ok := x.(set.IntTuple)
+	if !ok || xElem.Size() != l.s.Size() {
+		return false
+	}
+	r := make(set.IntTuple, xElem.Size())
+	copy(r, xElem)
+	col := 0
+	for _, b := range l.ech {
+		for ; b[col] == 0; col++ {
+			if r[col] != 0 {
+				return false
+			}
+		}
+		if r[col]%b[col] != 0 {
+			return false
+		}
+		axpy(r, -r[col]/b[col], b)
+	}
+	for ; col < r.Size(); col++ {
+		if r[col] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the formal name of the lattice.
+func (l Lattice) Name() string {
+	basis := make([]string, len(l.basis))
+	for i := range basis {
+		basis[i] = l.basis[i].String()
+	}
+	return fmt.Sprintf("〈%s〉", strings.Join(basis, ","))
+}
+
+// Identity returns the identity of the lattice, i.e. (0,0...).
+func (l Lattice) Identity() set.Elem {
+	return l.s.Identity()
+}
+
+func init() {
+	set.RegisterOp(Lattice{}.Add, set.Operation{Name: "addition", Symbol: "+"})
+}
+
+// Add is the + binary operation. It returns x + y.
+func (l Lattice) Add(x, y set.Elem) set.Elem {
+	return l.s.Add(x, y)
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (l Lattice) Inverse(x set.Elem) set.Elem {
+	return l.s.Inverse(x)
+}
@@ -0,0 +1,64 @@
+package lattice
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func TestNewRank(t *testing.T) {
+	s := set.NewIntTuple(3)
+	l := New(s, s.Tuple(1, 2, 3), s.Tuple(2, 4, 6), s.Tuple(0, 1, 1))
+	if want, got := 2, l.Rank(); want != got {
+		t.Errorf("expected rank %d but got %d (basis %v)", want, got, l.Basis())
+	}
+	if want, got := 0, New(s).Rank(); want != got {
+		t.Errorf("expected rank %d but got %d", want, got)
+	}
+}
+
+func TestIsIn(t *testing.T) {
+	s := set.NewIntTuple(2)
+	l := New(s, s.Tuple(2, 0), s.Tuple(1, 3))
+	for _, v := range []set.IntTuple{s.Tuple(0, 0), s.Tuple(2, 0), s.Tuple(1, 3), s.Tuple(3, 3), s.Tuple(-1, -3), s.Tuple(0, 6)} {
+		if !l.IsIn(v) {
+			t.Errorf("%s should be in the lattice %s", v, l.Name())
+		}
+	}
+	for _, v := range []set.IntTuple{s.Tuple(1, 0), s.Tuple(0, 3), s.Tuple(1, 1)} {
+		if l.IsIn(v) {
+			t.Errorf("%s should not be in the lattice %s", v, l.Name())
+		}
+	}
+	if v := set.NewIntTuple(3).Tuple(0, 0, 0); l.IsIn(v) {
+		t.Errorf("%s should not be in the lattice %s", v, l.Name())
+	}
+}
+
+func TestIsInDegenerate(t *testing.T) {
+	s := set.NewIntTuple(3)
+	l := New(s, s.Tuple(0, 2, 4))
+	if v := s.Tuple(0, 4, 8); !l.IsIn(v) {
+		t.Errorf("%s should be in the lattice %s", v, l.Name())
+	}
+	if v := s.Tuple(0, 2, 5); l.IsIn(v) {
+		t.Errorf("%s should not be in the lattice %s", v, l.Name())
+	}
+	if v := s.Tuple(1, 2, 4); l.IsIn(v) {
+		t.Errorf("%s should not be in the lattice %s", v, l.Name())
+	}
+}
+
+func TestGeneratorsInLattice(t *testing.T) {
+	s := set.NewIntTuple(3)
+	gens := []set.IntTuple{s.Tuple(4, -2, 7), s.Tuple(6, 3, 1), s.Tuple(-2, 5, 9)}
+	l := New(s, gens...)
+	for _, g := range gens {
+		if !l.IsIn(g) {
+			t.Errorf("generator %s should be in the lattice %s", g, l.Name())
+		}
+	}
+	if want, got := 3, l.Rank(); want != got {
+		t.Errorf("expected rank %d but got %d", want, got)
+	}
+}
@@ -0,0 +1,135 @@
+package lattice
+
+import (
+	"math/big"
+
+	"github.com/nickng/abelian/set"
+)
+
+// DefaultDelta is the Lovász parameter δ = 3/4 used by Lattice.Reduce.
+var DefaultDelta = big.NewRat(3, 4)
+
+// Reduce returns the same lattice with an LLL-reduced basis
+// using the Lovász parameter DefaultDelta.
+func (l Lattice) Reduce() Lattice {
+	return Lattice{s: l.s, basis: LLL(l.basis, DefaultDelta), ech: l.ech}
+}
+
+// LLL returns the Lenstra–Lenstra–Lovász reduction of the linearly
+// independent basis vectors with the Lovász parameter 1/4 < δ ≤ 1.
+//
+// The reduction is computed with exact rational arithmetic.
+// The input basis is not modified.
+func LLL(basis []set.IntTuple, delta *big.Rat) []set.IntTuple {
+	b := toBig(basis)
+	g := newGSO(b)
+	for k := 1; k < len(b); {
+		// Size reduction: |μ(k,j)| ≤ 1/2 for j < k.
+		for j := k - 1; j >= 0; j-- {
+			q := round(g.mu[k][j])
+			if q.Sign() == 0 {
+				continue
+			}
+			for i := range b[k] {
+				b[k][i].Sub(b[k][i], new(big.Int).Mul(q, b[j][i]))
+			}
+			qr := new(big.Rat).SetInt(q)
+			for i := 0; i < j; i++ {
+				g.mu[k][i].Sub(g.mu[k][i], new(big.Rat).Mul(qr, g.mu[j][i]))
+			}
+			g.mu[k][j].Sub(g.mu[k][j], qr)
+		}
+		// Lovász condition: B(k) ≥ (δ - μ(k,k-1)²)·B(k-1).
+		mu2 := new(big.Rat).Mul(g.mu[k][k-1], g.mu[k][k-1])
+		rhs := new(big.Rat).Sub(delta, mu2)
+		rhs.Mul(rhs, g.norm2[k-1])
+		if g.norm2[k].Cmp(rhs) >= 0 {
+			k++
+			continue
+		}
+		b[k], b[k-1] = b[k-1], b[k]
+		g = newGSO(b)
+		if k > 1 {
+			k--
+		}
+	}
+	return fromBig(b)
+}
+
+// gso is the Gram–Schmidt orthogonalisation of a basis b,
+// where b*(i) = b(i) - Σ_{j<i} μ(i,j)·b*(j).
+type gso struct {
+	star  [][]*big.Rat // star[i] is b*(i).
+	mu    [][]*big.Rat // mu[i][j] is μ(i,j) for j < i.
+	norm2 []*big.Rat   // norm2[i] is ‖b*(i)‖².
+}
+
+func newGSO(b [][]*big.Int) *gso {
+	g := &gso{
+		star:  make([][]*big.Rat, len(b)),
+		mu:    make([][]*big.Rat, len(b)),
+		norm2: make([]*big.Rat, len(b)),
+	}
+	for i := range b {
+		g.star[i] = make([]*big.Rat, len(b[i]))
+		for c := range b[i] {
+			g.star[i][c] = new(big.Rat).SetInt(b[i][c])
+		}
+		g.mu[i] = make([]*big.Rat, i)
+		for j := 0; j < i; j++ {
+			g.mu[i][j] = dotIntRat(b[i], g.star[j])
+			g.mu[i][j].Quo(g.mu[i][j], g.norm2[j])
+			for c := range g.star[i] {
+				g.star[i][c].Sub(g.star[i][c], new(big.Rat).Mul(g.mu[i][j], g.star[j][c]))
+			}
+		}
+		g.norm2[i] = dotRat(g.star[i], g.star[i])
+	}
+	return g
+}
+
+// round returns the nearest integer to r, rounding halves up.
+func round(r *big.Rat) *big.Int {
+	num := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	num.Add(num, r.Denom())
+	den := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+	return num.Div(num, den) // Euclidean division floors for den > 0.
+}
+
+func dotRat(x, y []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	for i := range x {
+		sum.Add(sum, new(big.Rat).Mul(x[i], y[i]))
+	}
+	return sum
+}
+
+func dotIntRat(x []*big.Int, y []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	for i := range x {
+		sum.Add(sum, new(big.Rat).Mul(new(big.Rat).SetInt(x[i]), y[i]))
+	}
+	return sum
+}
+
+func toBig(vs []set.IntTuple) [][]*big.Int {
+	b := make([][]*big.Int, len(vs))
+	for i := range vs {
+		b[i] = make([]*big.Int, vs[i].Size())
+		for c := range vs[i] {
+			b[i][c] = big.NewInt(int64(vs[i][c]))
+		}
+	}
+	return b
+}
+
+func fromBig(b [][]*big.Int) []set.IntTuple {
+	vs := make([]set.IntTuple, len(b))
+	for i := range b {
+		vs[i] = make(set.IntTuple, len(b[i]))
+		for c := range b[i] {
+			vs[i][c] = int(b[i][c].Int64())
+		}
+	}
+	return vs
+}
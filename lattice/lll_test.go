@@ -0,0 +1,74 @@
+package lattice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+// checkReduced checks b is size-reduced and satisfies the Lovász condition.
+func checkReduced(t *testing.T, b []set.IntTuple) {
+	g := newGSO(toBig(b))
+	half := big.NewRat(1, 2)
+	for k := 1; k < len(b); k++ {
+		for j := 0; j < k; j++ {
+			if new(big.Rat).Abs(g.mu[k][j]).Cmp(half) > 0 {
+				t.Errorf("basis %v is not size-reduced: μ(%d,%d)=%s", b, k, j, g.mu[k][j].RatString())
+			}
+		}
+		rhs := new(big.Rat).Sub(DefaultDelta, new(big.Rat).Mul(g.mu[k][k-1], g.mu[k][k-1]))
+		rhs.Mul(rhs, g.norm2[k-1])
+		if g.norm2[k].Cmp(rhs) < 0 {
+			t.Errorf("basis %v does not satisfy the Lovász condition at %d", b, k)
+		}
+	}
+}
+
+func TestLLL(t *testing.T) {
+	s := set.NewIntTuple(3)
+	basis := []set.IntTuple{s.Tuple(1, 1, 1), s.Tuple(-1, 0, 2), s.Tuple(3, 5, 6)}
+	reduced := LLL(basis, DefaultDelta)
+	checkReduced(t, reduced)
+	want := []set.IntTuple{s.Tuple(0, 1, 0), s.Tuple(1, 0, 1), s.Tuple(-2, 0, 1)}
+	for i := range want {
+		if want[i].Compare(reduced[i]) != 0 {
+			t.Errorf("expected reduced basis %v but got %v", want, reduced)
+			break
+		}
+	}
+	if basis[2].Compare(s.Tuple(3, 5, 6)) != 0 {
+		t.Errorf("LLL should not modify its input")
+	}
+}
+
+func TestReduceSameLattice(t *testing.T) {
+	s := set.NewIntTuple(4)
+	l := New(s, s.Tuple(105, 821, 404, 328), s.Tuple(881, 667, 644, 927), s.Tuple(181, 483, 87, 500), s.Tuple(893, 834, 732, 441))
+	r := l.Reduce()
+	checkReduced(t, r.Basis())
+	if want, got := l.Rank(), r.Rank(); want != got {
+		t.Errorf("expected rank %d but got %d", want, got)
+	}
+	for _, b := range r.Basis() {
+		if !l.IsIn(b) {
+			t.Errorf("reduced basis vector %s should be in %s", b, l.Name())
+		}
+	}
+	for _, b := range l.Basis() {
+		if !New(s, r.Basis()...).IsIn(b) {
+			t.Errorf("basis vector %s should be in %s", b, r.Name())
+		}
+	}
+}
+
+func TestReduceIsIn(t *testing.T) {
+	s := set.NewIntTuple(2)
+	r := New(s, s.Tuple(1, 0), s.Tuple(7, 2)).Reduce()
+	if v := s.Tuple(3, 4); !r.IsIn(v) {
+		t.Errorf("%s should be in the lattice %s", v, r.Name())
+	}
+	if v := s.Tuple(3, 3); r.IsIn(v) {
+		t.Errorf("%s should not be in the lattice %s", v, r.Name())
+	}
+}
@@ -0,0 +1,106 @@
+package lattice
+
+import (
+	"log"
+
+	"github.com/nickng/abelian/set"
+)
+
+// SmithNormalForm returns the diagonal d of the Smith normal form of
+// the m×n integer matrix with the given rows, i.e. U·A·V = diag(d) for
+// some unimodular U and V, where each d[i] ≥ 0 divides d[i+1] and the
+// zero entries come last. The length of d is min(m, n).
+//
+// If the rows are relations of ℤⁿ, the quotient ℤⁿ/〈rows〉 is isomorphic
+// to ℤ/d[0] x ... x ℤ/d[k-1] x ℤ^(n-k), where ℤ/0 = ℤ and ℤ/1 is trivial.
+func SmithNormalForm(rows []set.IntTuple) []int {
+	if len(rows) == 0 {
+		return nil
+	}
+	a := clone(rows)
+	m, n := len(a), a[0].Size()
+	for _, r := range a {
+		if r.Size() != n {
+			log.Fatal(set.MismatchDimErr{Dim1: r.Size(), Dim2: n})
+		}
+	}
+	k := m
+	if n < k {
+		k = n
+	}
+	d := make([]int, k)
+	for t := 0; t < k; t++ {
+		// Move the smallest nonzero entry of the submatrix to (t,t).
+		pi, pj := -1, -1
+		for i := t; i < m; i++ {
+			for j := t; j < n; j++ {
+				if a[i][j] != 0 && (pi < 0 || abs(a[i][j]) < abs(a[pi][pj])) {
+					pi, pj = i, j
+				}
+			}
+		}
+		if pi < 0 {
+			break // remaining entries are all zero.
+		}
+		swapRows(a, t, pi)
+		swapCols(a, t, pj)
+		for {
+			done := true
+			for i := t + 1; i < m; i++ {
+				axpy(a[i], -a[i][t]/a[t][t], a[t])
+				if a[i][t] != 0 {
+					done = false
+				}
+			}
+			for j := t + 1; j < n; j++ {
+				q := a[t][j] / a[t][t]
+				for i := t; i < m; i++ {
+					a[i][j] -= q * a[i][t]
+				}
+				if a[t][j] != 0 {
+					done = false
+				}
+			}
+			if done {
+				// The pivot must divide the rest of the submatrix,
+				// otherwise add the offending row and reduce again.
+				for i := t + 1; i < m && done; i++ {
+					for j := t + 1; j < n; j++ {
+						if a[i][j]%a[t][t] != 0 {
+							axpy(a[t], 1, a[i])
+							done = false
+							break
+						}
+					}
+				}
+				if done {
+					break
+				}
+				continue
+			}
+			// Move the smallest nonzero remainder in row/column t to (t,t).
+			for i := t + 1; i < m; i++ {
+				if a[i][t] != 0 && abs(a[i][t]) < abs(a[t][t]) {
+					swapRows(a, t, i)
+				}
+			}
+			for j := t + 1; j < n; j++ {
+				if a[t][j] != 0 && abs(a[t][j]) < abs(a[t][t]) {
+					swapCols(a, t, j)
+				}
+			}
+		}
+		d[t] = abs(a[t][t])
+	}
+	return d
+}
+
+func swapRows(a []set.IntTuple, i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func swapCols(a []set.IntTuple, i, j int) {
+	for _, r := range a {
+		r[i], r[j] = r[j], r[i]
+	}
+}
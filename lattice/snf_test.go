@@ -0,0 +1,54 @@
+package lattice
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func TestSmithNormalForm(t *testing.T) {
+	tests := []struct {
+		rows []set.IntTuple
+		want []int
+	}{
+		{[]set.IntTuple{{2, 4, 4}, {-6, 6, 12}, {10, -4, -16}}, []int{2, 6, 12}},
+		{[]set.IntTuple{{2, 0}, {0, 3}}, []int{1, 6}},
+		{[]set.IntTuple{{2, 4}, {3, 6}}, []int{1, 0}},
+		{[]set.IntTuple{{0, 0, 0}}, []int{0}},
+		{[]set.IntTuple{{4, 6, 8}}, []int{2}},
+		{[]set.IntTuple{{4}, {6}}, []int{2}},
+		{nil, nil},
+	}
+	for _, test := range tests {
+		if want, got := fmt.Sprint(test.want), fmt.Sprint(SmithNormalForm(test.rows)); want != got {
+			t.Errorf("expected Smith normal form of %v to be %s but got %s", test.rows, want, got)
+		}
+	}
+}
+
+func TestSmithNormalFormRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 200; n++ {
+		a := make([]set.IntTuple, 3)
+		for i := range a {
+			a[i] = set.IntTuple{rnd.Intn(21) - 10, rnd.Intn(21) - 10, rnd.Intn(21) - 10}
+		}
+		d := SmithNormalForm(a)
+		for i := 0; i+1 < len(d); i++ {
+			if d[i] == 0 && d[i+1] != 0 || d[i] != 0 && d[i+1]%d[i] != 0 {
+				t.Fatalf("expected %v to be a divisor chain for %v", d, a)
+			}
+		}
+		if want, got := abs(det3(a)), d[0]*d[1]*d[2]; want != got {
+			t.Errorf("expected product of %v to be |det %v| = %d but got %d", d, a, want, got)
+		}
+	}
+}
+
+func det3(a []set.IntTuple) int {
+	return a[0][0]*(a[1][1]*a[2][2]-a[1][2]*a[2][1]) -
+		a[0][1]*(a[1][0]*a[2][2]-a[1][2]*a[2][0]) +
+		a[0][2]*(a[1][0]*a[2][1]-a[1][1]*a[2][0])
+}
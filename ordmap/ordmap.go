@@ -0,0 +1,232 @@
+// Package ordmap implements an ordered map keyed by set.Elem.
+//
+// The keys of a Map are ordered by Elem.Compare, so all keys of
+// a Map must be comparable with each other, e.g. IntTuples of the
+// same size.
+//
+//	s := set.NewIntTuple(2)
+//	m := ordmap.New()
+//	m.Put(s.Tuple(1, 2), "a")
+//	m.Put(s.Tuple(5, 0), "b")
+//
+//	// Visit the entries in the box (0,0)..(3,3).
+//	m.RangeInterval(s.Interval(s.Tuple(0, 0), s.Tuple(3, 3)).(set.IntTupleInterval),
+//		func(k set.Elem, v interface{}) bool {
+//			fmt.Println(k, v) // (1,2) a
+//			return true
+//		})
+package ordmap
+
+import (
+	"math/rand"
+
+	"github.com/nickng/abelian/set"
+)
+
+// maxLevel is the maximum number of levels of the skip list.
+const maxLevel = 32
+
+// node is a skip list node.
+type node struct {
+	key   set.Elem
+	value interface{}
+	next  []*node
+}
+
+// Map is an ordered map from set.Elem to values,
+// implemented as a skip list.
+type Map struct {
+	head   *node
+	level  int
+	length int
+	rnd    *rand.Rand
+}
+
+// New returns a new empty Map.
+//
+// The random levels of the skip list are drawn from a fixed seed,
+// so the structure of the Map is deterministic.
+func New() *Map {
+	return &Map{
+		head:  &node{next: make([]*node, maxLevel)},
+		level: 1,
+		rnd:   rand.New(rand.NewSource(1)),
+	}
+}
+
+// Len returns the number of entries in the Map.
+func (m *Map) Len() int {
+	return m.length
+}
+
+// randomLevel returns the level of a new node, where
+// each level is promoted with probability 1/4.
+func (m *Map) randomLevel() int {
+	level := 1
+	for level < maxLevel && m.rnd.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+// seek returns the first node with key ≥ k, or nil if there is none.
+// If update is not nil, it is filled with the last node before k at each level.
+func (m *Map) seek(k set.Elem, update []*node) *node {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key.Compare(k) < 0 {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+// Get returns the value stored with key k,
+// and indicates if k is in the Map with ok.
+func (m *Map) Get(k set.Elem) (v interface{}, ok bool) {
+	if x := m.seek(k, nil); x != nil && x.key.Compare(k) == 0 {
+		return x.value, true
+	}
+	return nil, false
+}
+
+// Put stores the value v with key k, replacing the existing value of k.
+func (m *Map) Put(k set.Elem, v interface{}) {
+	var update [maxLevel]*node
+	if x := m.seek(k, update[:]); x != nil && x.key.Compare(k) == 0 {
+		x.value = v
+		return
+	}
+	if t, ok := k.(set.IntTuple); ok {
+		k = t.Clone() // the caller may modify t after Put.
+	}
+	level := m.randomLevel()
+	for ; m.level < level; m.level++ {
+		update[m.level] = m.head
+	}
+	x := &node{key: k, value: v, next: make([]*node, level)}
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+	}
+	m.length++
+}
+
+// Delete removes the entry with key k,
+// and returns true if k was in the Map.
+func (m *Map) Delete(k set.Elem) bool {
+	var update [maxLevel]*node
+	x := m.seek(k, update[:])
+	if x == nil || x.key.Compare(k) != 0 {
+		return false
+	}
+	for i := range x.next {
+		update[i].next[i] = x.next[i]
+	}
+	for m.level > 1 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.length--
+	return true
+}
+
+// Floor returns the entry with the greatest key ≤ k,
+// and indicates if there is such an entry with ok.
+func (m *Map) Floor(k set.Elem) (key set.Elem, v interface{}, ok bool) {
+	x := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key.Compare(k) <= 0 {
+			x = x.next[i]
+		}
+	}
+	if x == m.head {
+		return nil, nil, false
+	}
+	return x.key, x.value, true
+}
+
+// Ceiling returns the entry with the least key ≥ k,
+// and indicates if there is such an entry with ok.
+func (m *Map) Ceiling(k set.Elem) (key set.Elem, v interface{}, ok bool) {
+	x := m.seek(k, nil)
+	if x == nil {
+		return nil, nil, false
+	}
+	return x.key, x.value, true
+}
+
+// Ascend calls f for each entry of the Map in ascending order
+// of keys, until f returns false.
+func (m *Map) Ascend(f func(k set.Elem, v interface{}) bool) {
+	for x := m.head.next[0]; x != nil; x = x.next[0] {
+		if !f(x.key, x.value) {
+			return
+		}
+	}
+}
+
+// Range calls f for each entry with lo ≤ key ≤ hi in ascending order
+// of keys, until f returns false.
+func (m *Map) Range(lo, hi set.Elem, f func(k set.Elem, v interface{}) bool) {
+	for x := m.seek(lo, nil); x != nil && x.key.Compare(hi) <= 0; x = x.next[0] {
+		if !f(x.key, x.value) {
+			return
+		}
+	}
+}
+
+// RangeInterval calls f for each entry with an IntTuple key inside the box
+// of the interval iv, i.e. lo(i) ≤ key(i) ≤ hi(i) for every coordinate i,
+// in ascending order of keys, until f returns false.
+//
+// The entries are found by a lexicographic range scan from lo to hi.
+// Whenever the scan reaches a key outside the box, it skips ahead to
+// the next key which can be inside the box.
+func (m *Map) RangeInterval(iv set.IntTupleInterval, f func(k set.Elem, v interface{}) bool) {
+	lo, hi := iv.Lo(), iv.Hi()
+	for x := m.seek(lo, nil); x != nil && x.key.Compare(hi) <= 0; {
+		next, inside := skip(x.key.(set.IntTuple), lo, hi)
+		if inside {
+			if !f(x.key, x.value) {
+				return
+			}
+			x = x.next[0]
+			continue
+		}
+		if next == nil {
+			return
+		}
+		x = m.seek(next, nil)
+	}
+}
+
+// skip checks if k is inside the box lo..hi. If k is outside, it returns
+// the least key greater than k which can be inside the box, or nil if
+// there is none.
+func skip(k, lo, hi set.IntTuple) (next set.IntTuple, inside bool) {
+	for i := range k {
+		switch {
+		case k[i] < lo[i]:
+			// Smallest key in the box with prefix k[:i].
+			next = make(set.IntTuple, k.Size())
+			copy(next, k[:i])
+			copy(next[i:], lo[i:])
+			return next, false
+		case k[i] > hi[i]:
+			if i == 0 {
+				return nil, false
+			}
+			// No key with prefix k[:i] is in the box after k,
+			// so move to the next prefix k[:i-1], k[i-1]+1.
+			next = make(set.IntTuple, k.Size())
+			copy(next, k[:i-1])
+			next[i-1] = k[i-1] + 1
+			copy(next[i:], lo[i:])
+			return next, false
+		}
+	}
+	return nil, true
+}
@@ -0,0 +1,170 @@
+package ordmap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	s := set.NewIntTuple(2)
+	m := New()
+	m.Put(s.Tuple(1, 2), "a")
+	m.Put(s.Tuple(0, 5), "b")
+	m.Put(s.Tuple(1, 2), "c")
+	if want, got := 2, m.Len(); want != got {
+		t.Errorf("expected %d entries but got %d", want, got)
+	}
+	if v, ok := m.Get(s.Tuple(1, 2)); !ok || v != "c" {
+		t.Errorf("expected (1,2) to map to c but got %v (ok=%t)", v, ok)
+	}
+	if _, ok := m.Get(s.Tuple(2, 1)); ok {
+		t.Errorf("expected (2,1) not to be in the map")
+	}
+	if !m.Delete(s.Tuple(0, 5)) {
+		t.Errorf("expected (0,5) to be deleted")
+	}
+	if m.Delete(s.Tuple(0, 5)) {
+		t.Errorf("expected (0,5) not to be in the map")
+	}
+	if want, got := 1, m.Len(); want != got {
+		t.Errorf("expected %d entries but got %d", want, got)
+	}
+}
+
+func TestPutCopies(t *testing.T) {
+	s := set.NewIntTuple(2)
+	m := New()
+	k := s.Tuple(1, 2)
+	m.Put(k, "a")
+	k[0] = 3
+	if v, ok := m.Get(s.Tuple(1, 2)); !ok || v != "a" {
+		t.Errorf("expected (1,2) to map to a but got %v (ok=%t)", v, ok)
+	}
+	if _, ok := m.Get(s.Tuple(3, 2)); ok {
+		t.Errorf("expected (3,2) not to be in the map")
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	s := set.NewIntTuple(1)
+	m := New()
+	for _, v := range []int{10, 20, 30} {
+		m.Put(s.Tuple(v), v)
+	}
+	tests := []struct {
+		k              int
+		floor, ceiling interface{}
+	}{
+		{5, nil, 10},
+		{10, 10, 10},
+		{15, 10, 20},
+		{30, 30, 30},
+		{35, 30, nil},
+	}
+	for _, tt := range tests {
+		_, f, _ := m.Floor(s.Tuple(tt.k))
+		_, c, _ := m.Ceiling(s.Tuple(tt.k))
+		if f != tt.floor {
+			t.Errorf("expected Floor(%d) = %v but got %v", tt.k, tt.floor, f)
+		}
+		if c != tt.ceiling {
+			t.Errorf("expected Ceiling(%d) = %v but got %v", tt.k, tt.ceiling, c)
+		}
+	}
+}
+
+// TestRandom checks the Map against a sorted slice.
+func TestRandom(t *testing.T) {
+	s := set.NewIntTuple(2)
+	rnd := rand.New(rand.NewSource(42))
+	m := New()
+	ref := make(map[[2]int]int)
+	for i := 0; i < 2000; i++ {
+		k := [2]int{rnd.Intn(20), rnd.Intn(20)}
+		if rnd.Intn(3) == 0 {
+			_, want := ref[k]
+			delete(ref, k)
+			if got := m.Delete(s.Tuple(k[0], k[1])); want != got {
+				t.Fatalf("Delete(%v): expected %t but got %t", k, want, got)
+			}
+		} else {
+			ref[k] = i
+			m.Put(s.Tuple(k[0], k[1]), i)
+		}
+	}
+	var keys []set.IntTuple
+	for k := range ref {
+		keys = append(keys, set.IntTuple{k[0], k[1]})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Compare(keys[j]) < 0 })
+	if want, got := len(keys), m.Len(); want != got {
+		t.Fatalf("expected %d entries but got %d", want, got)
+	}
+	i := 0
+	m.Ascend(func(k set.Elem, v interface{}) bool {
+		if keys[i].Compare(k) != 0 {
+			t.Errorf("expected key %s at %d but got %s", keys[i], i, k)
+		}
+		if want := ref[[2]int{keys[i][0], keys[i][1]}]; want != v {
+			t.Errorf("expected value %d for %s but got %v", want, k, v)
+		}
+		i++
+		return true
+	})
+}
+
+func TestRange(t *testing.T) {
+	s := set.NewIntTuple(1)
+	m := New()
+	for v := 0; v < 10; v++ {
+		m.Put(s.Tuple(v), v)
+	}
+	var got []interface{}
+	m.Range(s.Tuple(3), s.Tuple(6), func(k set.Elem, v interface{}) bool {
+		got = append(got, v)
+		return v != 5
+	})
+	if want := []interface{}{3, 4, 5}; len(want) != len(got) || want[0] != got[0] || want[2] != got[2] {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+}
+
+func TestRangeInterval(t *testing.T) {
+	s := set.NewIntTuple(3)
+	rnd := rand.New(rand.NewSource(7))
+	m := New()
+	for i := 0; i < 500; i++ {
+		m.Put(s.Tuple(rnd.Intn(10), rnd.Intn(10), rnd.Intn(10)), i)
+	}
+	iv := s.Interval(s.Tuple(2, 3, 1), s.Tuple(6, 5, 4)).(set.IntTupleInterval)
+	var want []set.Elem
+	m.Ascend(func(k set.Elem, v interface{}) bool {
+		x := k.(set.IntTuple)
+		for i := range x {
+			if x[i] < iv.Lo()[i] || x[i] > iv.Hi()[i] {
+				return true
+			}
+		}
+		want = append(want, k)
+		return true
+	})
+	var got []set.Elem
+	m.RangeInterval(iv, func(k set.Elem, v interface{}) bool {
+		got = append(got, k)
+		return true
+	})
+	if len(want) == 0 {
+		t.Fatalf("expected some keys in the box")
+	}
+	if len(want) != len(got) {
+		t.Fatalf("expected %d keys in %s but got %d", len(want), iv.Name(), len(got))
+	}
+	for i := range want {
+		if want[i].Compare(got[i]) != 0 {
+			t.Errorf("expected %s at %d but got %s", want[i], i, got[i])
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package abelian
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// Product returns the direct product G₀ × G₁ × … of the groups, whose
+// elements are ProductElems and whose operation is applied componentwise.
+//
+// The Set of the product is a ProductSet, which also implements
+// prop.Invertible if the Sets of all the groups do.
+func Product(gs ...Group) Group {
+	p := ProductSet{groups: append([]Group(nil), gs...)}
+	for _, g := range gs {
+		if _, ok := g.Set.(prop.Invertible); !ok {
+			return New(p, p.Op)
+		}
+	}
+	return New(invertibleProduct{p}, p.Op)
+}
+
+// Factors returns the groups of g if g is a direct product
+// created by Product, and false otherwise.
+func (g Group) Factors() ([]Group, bool) {
+	switch p := g.Set.(type) {
+	case ProductSet:
+		return p.Groups(), true
+	case invertibleProduct:
+		return p.Groups(), true
+	}
+	return nil, false
+}
+
+// ProductSet is the set of a direct product of groups.
+type ProductSet struct {
+	groups []Group
+}
+
+// Groups returns the factors of the product.
+func (p ProductSet) Groups() []Group {
+	return append([]Group(nil), p.groups...)
+}
+
+// IsIn returns true if each component of x is in the corresponding group.
+func (p ProductSet) IsIn(x set.Elem) bool {
+	xElem, ok := x.(ProductElem)
+	if !ok || len(xElem) != len(p.groups) {
+		return false
+	}
+	for i, g := range p.groups {
+		if !g.IsIn(xElem[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the formal name of the product, e.g. ℤxℤ × ℤ/3.
+func (p ProductSet) Name() string {
+	names := make([]string, len(p.groups))
+	for i, g := range p.groups {
+		names[i] = g.Name()
+	}
+	return strings.Join(names, " × ")
+}
+
+// Identity returns the tuple of the identities of the groups.
+func (p ProductSet) Identity() set.Elem {
+	id := make(ProductElem, len(p.groups))
+	for i, g := range p.groups {
+		id[i] = g.Identity()
+	}
+	return id
+}
+
+func init() {
+	set.RegisterOp(ProductSet{}.Op, set.Operation{Name: "direct sum", Symbol: "⊕"})
+}
+
+// Op is the componentwise operation of the groups.
+func (p ProductSet) Op(x, y set.Elem) set.Elem {
+	xElem, yElem := x.(ProductElem), y.(ProductElem)
+	z := make(ProductElem, len(p.groups))
+	for i, g := range p.groups {
+		z[i] = g.Op(xElem[i], yElem[i])
+	}
+	return z
+}
+
+// ParseElem parses a tuple of the elements of the groups, e.g. ((1,2),1),
+// where the Set of each group must implement set.ElemParser.
+func (p ProductSet) ParseElem(text string) (set.Elem, error) {
+	parts, offsets, err := splitTuple(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) != len(p.groups) {
+		return nil, set.ParseError{Text: text, Msg: fmt.Sprintf("expecting %d components but got %d", len(p.groups), len(parts))}
+	}
+	x := make(ProductElem, len(parts))
+	for i, g := range p.groups {
+		x[i], err = set.ParseElem(g.Set, parts[i])
+		if err != nil {
+			if perr, ok := err.(set.ParseError); ok {
+				perr.Text, perr.Pos = text, offsets[i]+perr.Pos
+				return nil, perr
+			}
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// splitTuple splits "(a,b,...)" at the top-level commas,
+// and returns the components with their offsets in text.
+func splitTuple(text string) (parts []string, offsets []int, err error) {
+	start := strings.IndexByte(text, '(')
+	end := strings.LastIndexByte(text, ')')
+	if start < 0 || end < start || strings.TrimSpace(text[:start]) != "" || strings.TrimSpace(text[end+1:]) != "" {
+		return nil, nil, set.ParseError{Text: text, Msg: "expecting parenthesised tuple"}
+	}
+	depth, from := 0, start+1
+	for i := start + 1; i < end; i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts, offsets = append(parts, text[from:i]), append(offsets, from)
+				from = i + 1
+			}
+		}
+	}
+	return append(parts, text[from:end]), append(offsets, from), nil
+}
+
+// invertibleProduct is a ProductSet of groups with invertible Sets.
+type invertibleProduct struct {
+	ProductSet
+}
+
+// Inverse returns the componentwise inverse of x.
+func (p invertibleProduct) Inverse(x set.Elem) set.Elem {
+	xElem := x.(ProductElem)
+	inv := make(ProductElem, len(p.groups))
+	for i, g := range p.groups {
+		inv[i] = g.Set.(prop.Invertible).Inverse(xElem[i])
+	}
+	return inv
+}
+
+// ProductElem is an Elem of a ProductSet, with one Elem of each group.
+type ProductElem []set.Elem
+
+// String returns the tuple of the components, e.g. ((1,2),1).
+func (e ProductElem) String() string {
+	s := make([]string, len(e))
+	for i, x := range e {
+		s[i] = x.String()
+	}
+	return "(" + strings.Join(s, ",") + ")"
+}
+
+// Compare compares the components of e and x lexicographically.
+func (e ProductElem) Compare(x set.Elem) int {
+	xElem := x.(ProductElem)
+	for i := range e {
+		if c := e[i].Compare(xElem[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Key returns the Keys of the components of e,
+// each prefixed by its length, as a string.
+func (e ProductElem) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	var buf []byte
+	for _, x := range e {
+		k := set.Key(x)
+		buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(len(k)))]...)
+		buf = append(buf, k...)
+	}
+	return string(buf)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of e.
+func (e ProductElem) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(e.Key()))
+	return h.Sum64()
+}
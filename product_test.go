@@ -0,0 +1,103 @@
+package abelian_test
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/internal/settest"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+func TestProduct(t *testing.T) {
+	s, m := set.NewIntTuple(2), set.NewMod(3)
+	g := abelian.Product(abelian.New(s, s.Add), abelian.New(m, m.Add))
+	if want, got := "ℤxℤ × ℤ/3", g.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	x := abelian.ProductElem{s.Tuple(1, 2), set.ModInt(2)}
+	y := abelian.ProductElem{s.Tuple(0, -1), set.ModInt(2)}
+	if want, got := "((1,1),1)", g.Op(x, y).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "((0,0),0)", g.Identity().String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	z, err := g.Sub(x, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 0, z.Compare(g.Identity()); want != got {
+		t.Errorf("expected x - x to be the identity but got %v", z)
+	}
+	if !g.IsIn(x) || g.IsIn(abelian.ProductElem{s.Tuple(1, 2)}) || g.IsIn(abelian.ProductElem{s.Tuple(1, 2), set.ModInt(3)}) {
+		t.Errorf("unexpected membership of %s", g.Name())
+	}
+	if want, got := -1, y.Compare(x); want != got {
+		t.Errorf("expected %v compared with %v to be %d but got %d", y, x, want, got)
+	}
+}
+
+func TestProductFactors(t *testing.T) {
+	s, m := set.NewIntTuple(2), set.NewMod(3)
+	g := abelian.Product(abelian.New(s, s.Add), abelian.New(m, m.Add))
+	gs, ok := g.Factors()
+	if !ok || len(gs) != 2 || gs[1].Name() != "ℤ/3" {
+		t.Errorf("expected factors of %s but got %v (ok=%t)", g.Name(), gs, ok)
+	}
+	n := abelian.Product(abelian.New(s, s.Add), abelian.New(settest.NonInvertible{IntTupleSet: s}, s.Add))
+	if gs, ok := n.Factors(); !ok || len(gs) != 2 {
+		t.Errorf("expected factors of %s but got %v (ok=%t)", n.Name(), gs, ok)
+	}
+	if _, ok := abelian.New(s, s.Add).Factors(); ok {
+		t.Errorf("expected %s not to be a product", s.Name())
+	}
+}
+
+func TestProductKey(t *testing.T) {
+	f, m := set.NewFreeAbelian(), set.NewMod(3)
+	g := abelian.Product(abelian.New(f, f.Add), abelian.New(m, m.Add))
+	x := abelian.ProductElem{f.Basis(1), set.ModInt(2)}
+	y := abelian.ProductElem{f.Basis("1"), set.ModInt(2)}
+	if x.String() != y.String() || set.Key(x) == set.Key(y) {
+		t.Errorf("expected %v and %v to print the same but have different keys", x, y)
+	}
+	if want, got := set.Key(x), set.Key(abelian.ProductElem{f.Basis(1), set.ModInt(2)}); want != got {
+		t.Errorf("expected key %q but got %q", want, got)
+	}
+	if want, got := 2, set.NewFiniteSet(g.Set, x, y).Len(); want != got {
+		t.Errorf("expected %d Elems but got %d", want, got)
+	}
+}
+
+func TestProductNotInvertible(t *testing.T) {
+	s, n := set.NewIntTuple(1), settest.NonInvertible{IntTupleSet: set.NewIntTuple(1)}
+	g := abelian.Product(abelian.New(s, s.Add), abelian.New(n, n.Add))
+	if _, ok := g.Set.(prop.Invertible); ok {
+		t.Errorf("product with a non-invertible group should not be invertible")
+	}
+	if _, ok := g.Set.(abelian.ProductSet); !ok {
+		t.Errorf("expected Set to be abelian.ProductSet but got %T", g.Set)
+	}
+}
+
+func TestProductParse(t *testing.T) {
+	s, m := set.NewIntTuple(2), set.NewMod(3)
+	g := abelian.Product(abelian.New(s, s.Add), abelian.New(m, m.Add))
+	x, err := set.ParseElem(g.Set, "((1, 2), 4)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "((1,2),1)", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	for _, text := range []string{"(1,2)", "((1,2),1,1)", "((1,2),x)", "((1,2),1) + 1"} {
+		if _, err := set.ParseElem(g.Set, text); err == nil {
+			t.Errorf("expected error parsing %q", text)
+		}
+	}
+	_, err = set.ParseElem(g.Set, "((1,2),x)")
+	if perr, ok := err.(set.ParseError); !ok || perr.Pos != 7 || perr.Text != "((1,2),x)" {
+		t.Errorf("expected parse error at position 7 but got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package abelian
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// reduceGrain is the number of Elems reduced sequentially
+// by each leaf of a parallel reduction.
+const reduceGrain = 1024
+
+// Reduce returns x₀·x₁·…·xₙ₋₁, or the identity if xs is empty.
+func (g Group) Reduce(xs []set.Elem) set.Elem {
+	sum := g.Identity()
+	for _, x := range xs {
+		sum = g.Op(sum, x)
+	}
+	return sum
+}
+
+// Sum returns the sum (under Op) of all the Elems of e,
+// or the identity if e is empty.
+func (g Group) Sum(e set.Enumerable) set.Elem {
+	sum := g.Identity()
+	if isEmpty(e) {
+		return sum
+	}
+	it := e.Enumerate()
+	for {
+		next, more := it.Next()
+		if next != nil {
+			sum = g.Op(sum, next)
+		}
+		if !more {
+			return sum
+		}
+	}
+}
+
+// ParallelReduce returns the same result as Reduce, splitting the work
+// across the given number of goroutines (GOMAXPROCS if workers ≤ 0).
+//
+// The Elems are reduced in fixed-size blocks, and the block results are
+// combined in a balanced tree in order. The shape of the reduction only
+// depends on len(xs), so the result does not depend on the number of
+// workers or the scheduling of goroutines.
+func (g Group) ParallelReduce(xs []set.Elem, workers int) set.Elem {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	blocks := (len(xs) + reduceGrain - 1) / reduceGrain
+	if blocks <= 1 {
+		return g.Reduce(xs)
+	}
+	sums := make([]set.Elem, blocks)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < blocks; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range next {
+				end := (b + 1) * reduceGrain
+				if end > len(xs) {
+					end = len(xs)
+				}
+				sums[b] = g.Reduce(xs[b*reduceGrain : end])
+			}
+		}()
+	}
+	for b := 0; b < blocks; b++ {
+		next <- b
+	}
+	close(next)
+	wg.Wait()
+	return g.tree(sums)
+}
+
+// tree combines the sums pairwise in a balanced tree, in order.
+func (g Group) tree(sums []set.Elem) set.Elem {
+	for len(sums) > 1 {
+		half := make([]set.Elem, (len(sums)+1)/2)
+		for i := range half {
+			if 2*i+1 < len(sums) {
+				half[i] = g.Op(sums[2*i], sums[2*i+1])
+			} else {
+				half[i] = sums[2*i]
+			}
+		}
+		sums = half
+	}
+	return sums[0]
+}
+
+// ParallelSum returns the same result as Sum, splitting the work across
+// the given number of goroutines (GOMAXPROCS if workers ≤ 0).
+//
+// The Elems of e are enumerated in blocks of the same size as
+// ParallelReduce, so only the blocks being reduced and the block
+// results are kept in memory, not all the Elems of e.
+func (g Group) ParallelSum(e set.Enumerable, workers int) set.Elem {
+	if isEmpty(e) {
+		return g.Identity()
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	type block struct {
+		i  int
+		xs []set.Elem
+	}
+	blocks := make(chan block, workers)
+	var mu sync.Mutex
+	var sums []set.Elem
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range blocks {
+				sum := g.Reduce(b.xs)
+				mu.Lock()
+				sums[b.i] = sum
+				mu.Unlock()
+			}
+		}()
+	}
+	it := e.Enumerate()
+	xs := make([]set.Elem, 0, reduceGrain)
+	for more := true; more; {
+		var next set.Elem
+		next, more = it.Next()
+		if next != nil {
+			xs = append(xs, next)
+		}
+		if len(xs) == reduceGrain || !more && len(xs) > 0 {
+			mu.Lock()
+			i := len(sums)
+			sums = append(sums, nil)
+			mu.Unlock()
+			blocks <- block{i: i, xs: xs}
+			xs = make([]set.Elem, 0, reduceGrain)
+		}
+	}
+	close(blocks)
+	wg.Wait()
+	if len(sums) == 0 {
+		return g.Identity()
+	}
+	return g.tree(sums)
+}
+
+// isEmpty returns true if e is known to be empty, i.e. e is
+// prop.Finite with cardinality 0. The iterators of some empty
+// sets, e.g. an empty interval, still return an Elem.
+func isEmpty(e set.Enumerable) bool {
+	f, ok := e.(prop.Finite)
+	return ok && f.Cardinality() == 0
+}
@@ -0,0 +1,92 @@
+package abelian_test
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+)
+
+func TestReduce(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	xs := []set.Elem{s.Tuple(1, 2), s.Tuple(3, 4), s.Tuple(-1, 0)}
+	if want, got := s.Tuple(3, 6), g.Reduce(xs); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := s.Identity(), g.Reduce(nil); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestSum(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	iv := s.Interval(s.Tuple(1, 1), s.Tuple(3, 2))
+	if want, got := s.Tuple(12, 9), g.Sum(iv); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	empty := s.Ball(s.Identity(), -1, set.L1)
+	if want, got := s.Identity(), g.Sum(empty); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	s1 := set.NewIntTuple(1)
+	g1 := abelian.New(s1, s1.Add)
+	emptyInterval := s1.Interval(s1.Tuple(2), s1.Tuple(1))
+	if want, got := s1.Identity(), g1.Sum(emptyInterval); want.Compare(got) != 0 {
+		t.Errorf("expected sum of empty interval %s but got %s", want, got)
+	}
+	if want, got := s1.Identity(), g1.ParallelSum(emptyInterval, 2); want.Compare(got) != 0 {
+		t.Errorf("expected parallel sum of empty interval %s but got %s", want, got)
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	s := set.NewIntTuple(3)
+	g := abelian.New(s, s.Add)
+	iv := s.Interval(s.Tuple(-10, 0, 5), s.Tuple(10, 20, 30))
+	want := g.Sum(iv)
+	for _, workers := range []int{0, 1, 2, 7} {
+		if got := g.ParallelSum(iv, workers); want.Compare(got) != 0 {
+			t.Errorf("workers=%d: expected %s but got %s", workers, want, got)
+		}
+	}
+	if want, got := s.Identity(), g.ParallelReduce(nil, 4); want.Compare(got) != 0 {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+// enumerateOnly is an interval which cannot be sliced,
+// to check ParallelSum enumerates its Elems.
+type enumerateOnly struct{ set.IntTupleInterval }
+
+func (enumerateOnly) Slice() []set.Elem { panic("unexpected Slice") }
+
+func TestParallelSumEnumerates(t *testing.T) {
+	s := set.NewIntTuple(2)
+	g := abelian.New(s, s.Add)
+	iv := s.Interval(s.Tuple(0, 0), s.Tuple(99, 49)).(set.IntTupleInterval)
+	want := g.Sum(iv)
+	for _, workers := range []int{1, 3} {
+		if got := g.ParallelSum(enumerateOnly{iv}, workers); want.Compare(got) != 0 {
+			t.Errorf("workers=%d: expected %s but got %s", workers, want, got)
+		}
+	}
+}
+
+// TestParallelReduceDeterministic checks the order of reduction does not
+// depend on the number of workers, using a non-commutative operation.
+func TestParallelReduceDeterministic(t *testing.T) {
+	s := set.NewIntTuple(1)
+	// x·y = 2x + y is not associative nor commutative.
+	g := abelian.New(s, func(x, y set.Elem) set.Elem {
+		return s.Tuple((2*x.(set.IntTuple)[0] + y.(set.IntTuple)[0]) % 1000003)
+	})
+	xs := s.Interval(s.Tuple(0), s.Tuple(10000)).Slice()
+	want := g.ParallelReduce(xs, 1)
+	for _, workers := range []int{2, 3, 8} {
+		if got := g.ParallelReduce(xs, workers); want.Compare(got) != 0 {
+			t.Errorf("workers=%d: expected %s but got %s", workers, want, got)
+		}
+	}
+}
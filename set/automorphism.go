@@ -0,0 +1,147 @@
+package set
+
+import (
+	"fmt"
+	"log"
+)
+
+// Automorphism is an automorphism of ℤ^n, the map x ↦ A·x for a
+// unimodular matrix A ∈ GL_n(ℤ), i.e. an n×n integer matrix with
+// determinant ±1, which is a bijection of ℤ^n preserving Add.
+type Automorphism struct {
+	a, inv Matrix
+}
+
+// NewAutomorphism returns the automorphism x ↦ A·x, and false
+// if A is not unimodular and does not define an automorphism.
+func NewAutomorphism(a Matrix) (Automorphism, bool) {
+	inv, ok := a.Inverse()
+	if !ok {
+		return Automorphism{}, false
+	}
+	return Automorphism{a: a, inv: inv}, true
+}
+
+// IdentityAutomorphism returns the identity automorphism of ℤ^n.
+func IdentityAutomorphism(n int) Automorphism {
+	return Automorphism{a: IdentityMatrix(n), inv: IdentityMatrix(n)}
+}
+
+// Permutation returns the automorphism of ℤ^n which moves the i-th
+// coordinate to the perm[i]-th coordinate, where perm is a permutation
+// of 0..n-1, e.g. Permutation(1, 0) swaps the coordinates of ℤ^2.
+func Permutation(perm ...int) Automorphism {
+	n := len(perm)
+	a := Matrix{m: n, n: n, a: make([]int, n*n)}
+	seen := make([]bool, n)
+	for i, p := range perm {
+		if p < 0 || p >= n || seen[p] {
+			log.Fatalf("cannot create permutation %v: not a permutation of 0..%d", perm, n-1)
+		}
+		seen[p] = true
+		a.a[p*n+i] = 1
+	}
+	return Automorphism{a: a, inv: a.Transpose()}
+}
+
+// Reflection returns the automorphism of ℤ^n which negates the
+// coordinate axis, e.g. (x,y) ↦ (-x,y) for axis 0 of ℤ^2.
+func Reflection(n, axis int) Automorphism {
+	if axis < 0 || axis >= n {
+		log.Fatalf("axis %d out of range of ℤ^%d", axis, n)
+	}
+	a := IdentityMatrix(n)
+	a.a[axis*n+axis] = -1
+	return Automorphism{a: a, inv: a}
+}
+
+// Skew returns the automorphism of ℤ^n which adds k times the j-th
+// coordinate to the i-th coordinate, where i ≠ j, e.g. (x,y) ↦ (x+2y,y)
+// for i = 0, j = 1, k = 2 in ℤ^2.
+func Skew(n, i, j, k int) Automorphism {
+	if i < 0 || i >= n || j < 0 || j >= n || i == j {
+		log.Fatalf("cannot create skew of coordinates %d and %d of ℤ^%d", i, j, n)
+	}
+	a, inv := IdentityMatrix(n), IdentityMatrix(n)
+	a.a[i*n+j], inv.a[i*n+j] = k, -k
+	return Automorphism{a: a, inv: inv}
+}
+
+// Rotation2D returns the rotation of ℤ^2 by quarters
+// counterclockwise quarter turns, i.e. (x,y) ↦ (-y,x) for each turn.
+func Rotation2D(quarters int) Automorphism {
+	turn := Automorphism{a: NewMatrix(2, 2).Matrix(IntTuple{0, -1}, IntTuple{1, 0})}
+	turn.inv = turn.a.Transpose()
+	return turn.Pow(((quarters % 4) + 4) % 4)
+}
+
+// Rotation3D returns the rotation of ℤ^3 about the coordinate axis by
+// quarters counterclockwise quarter turns, following the right-hand rule,
+// e.g. (x,y,z) ↦ (-y,x,z) for each turn about axis 2.
+func Rotation3D(axis, quarters int) Automorphism {
+	if axis < 0 || axis >= 3 {
+		log.Fatalf("axis %d out of range of ℤ^3", axis)
+	}
+	a := IdentityMatrix(3)
+	i, j := (axis+1)%3, (axis+2)%3 // the plane of rotation.
+	a.a[i*3+i], a.a[j*3+j] = 0, 0
+	a.a[i*3+j], a.a[j*3+i] = -1, 1
+	return Automorphism{a: a, inv: a.Transpose()}.Pow(((quarters % 4) + 4) % 4)
+}
+
+// Size returns n, the tuple size of ℤ^n.
+func (f Automorphism) Size() int {
+	return f.a.n
+}
+
+// Matrix returns the unimodular matrix A of x ↦ A·x.
+func (f Automorphism) Matrix() Matrix {
+	return f.a
+}
+
+// Apply returns f(x) = A·x.
+func (f Automorphism) Apply(x IntTuple) IntTuple {
+	return f.a.Apply(x)
+}
+
+// Inverse returns the inverse automorphism x ↦ A⁻¹·x.
+func (f Automorphism) Inverse() Automorphism {
+	return Automorphism{a: f.inv, inv: f.a}
+}
+
+// Compose returns the automorphism f∘g, i.e. x ↦ f(g(x)).
+func (f Automorphism) Compose(g Automorphism) Automorphism {
+	if f.Size() != g.Size() {
+		log.Fatal(MismatchDimErr{Dim1: f.Size(), Dim2: g.Size()})
+	}
+	return Automorphism{a: f.a.Mul(g.a), inv: g.inv.Mul(f.inv)}
+}
+
+// Pow returns f composed with itself k times,
+// where negative k uses the inverse of f.
+func (f Automorphism) Pow(k int) Automorphism {
+	if k < 0 {
+		f, k = f.Inverse(), -k
+	}
+	g := IdentityAutomorphism(f.Size())
+	for ; k > 0; k >>= 1 { // repeated squaring.
+		if k&1 == 1 {
+			g = g.Compose(f)
+		}
+		f = f.Compose(f)
+	}
+	return g
+}
+
+// Image returns the image of the interval r under f, a parallelepiped.
+func (f Automorphism) Image(r IntTupleInterval) IntTupleParallelepiped {
+	if r.lo.Size() != f.Size() {
+		log.Fatal(MismatchDimErr{Dim1: r.lo.Size(), Dim2: f.Size()})
+	}
+	return IntTupleParallelepiped{Set: r.Set, box: r, f: f}
+}
+
+// String returns the description of f, e.g. x ↦ ((0,-1),(1,0))·x.
+func (f Automorphism) String() string {
+	return fmt.Sprintf("x ↦ %s·x", f.a)
+}
@@ -0,0 +1,78 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestAutomorphism(t *testing.T) {
+	a := NewMatrix(2, 2).Matrix(IntTuple{2, 1}, IntTuple{1, 1})
+	f, ok := NewAutomorphism(a)
+	if !ok {
+		t.Fatalf("expected %v to define an automorphism", a)
+	}
+	x := IntTuple{3, -4}
+	if want, got := "(2,-1)", f.Apply(x).String(); want != got {
+		t.Errorf("expected f%v = %s but got %s", x, want, got)
+	}
+	if want, got := x, f.Inverse().Apply(f.Apply(x)); want.Compare(got) != 0 {
+		t.Errorf("expected f⁻¹(f(x)) = %v but got %v", want, got)
+	}
+	if want, got := IdentityMatrix(2), f.Compose(f.Inverse()).Matrix(); want.Compare(got) != 0 {
+		t.Errorf("expected f∘f⁻¹ = %v but got %v", want, got)
+	}
+	// f(x + y) = f(x) + f(y).
+	s, y := NewIntTuple(2), IntTuple{1, 5}
+	if want, got := s.Add(f.Apply(x), f.Apply(y)), f.Apply(s.Add(x, y).(IntTuple)); want.Compare(got) != 0 {
+		t.Errorf("expected f(x + y) = %v but got %v", want, got)
+	}
+	if _, ok := NewAutomorphism(NewMatrix(2, 2).Matrix(IntTuple{2, 0}, IntTuple{0, 1})); ok {
+		t.Errorf("expected non-unimodular matrix not to define an automorphism")
+	}
+}
+
+func TestAutomorphismGenerators(t *testing.T) {
+	x := IntTuple{1, 2, 3}
+	for _, tc := range []struct {
+		f    Automorphism
+		want string
+	}{
+		{Permutation(1, 2, 0), "(3,1,2)"},
+		{Reflection(3, 1), "(1,-2,3)"},
+		{Skew(3, 0, 2, 2), "(7,2,3)"},
+		{Rotation3D(2, 1), "(-2,1,3)"},
+		{Rotation3D(0, 1), "(1,-3,2)"},
+		{Rotation3D(1, -1), "(-3,2,1)"},
+		{Rotation3D(1, 4), "(1,2,3)"},
+		{Rotation3D(2, 1000000001), "(-2,1,3)"},
+		{Rotation3D(2, -1000000003), "(-2,1,3)"},
+	} {
+		if got := tc.f.Apply(x).String(); tc.want != got {
+			t.Errorf("expected %v%v = %s but got %s", tc.f, x, tc.want, got)
+		}
+		if want, got := x, tc.f.Inverse().Apply(tc.f.Apply(x)); want.Compare(got) != 0 {
+			t.Errorf("expected inverse of %v to map back to %v but got %v", tc.f, want, got)
+		}
+	}
+	if want, got := "(-1,-2)", Rotation2D(2).Apply(IntTuple{1, 2}).String(); want != got {
+		t.Errorf("expected half turn %s but got %s", want, got)
+	}
+	if want, got := Rotation2D(-1).Matrix(), Rotation2D(3).Matrix(); want.Compare(got) != 0 {
+		t.Errorf("expected -1 quarter turn %v but got %v", want, got)
+	}
+}
+
+func TestAutomorphismPow(t *testing.T) {
+	f, x := Skew(2, 0, 1, 1), IntTuple{1, 2}
+	if want, got := "(2000000001,2)", f.Pow(1000000000).Apply(x).String(); want != got {
+		t.Errorf("expected %v^1000000000%v = %s but got %s", f, x, want, got)
+	}
+	if want, got := "(-1999999999,2)", f.Pow(-1000000000).Apply(x).String(); want != got {
+		t.Errorf("expected %v^-1000000000%v = %s but got %s", f, x, want, got)
+	}
+	if want, got := f.Compose(f).Compose(f).Matrix(), f.Pow(3).Matrix(); want.Compare(got) != 0 {
+		t.Errorf("expected f³ = %v but got %v", want, got)
+	}
+	if want, got := IdentityMatrix(2), f.Pow(0).Matrix(); want.Compare(got) != 0 {
+		t.Errorf("expected f⁰ = %v but got %v", want, got)
+	}
+}
@@ -0,0 +1,191 @@
+package set
+
+import (
+	"fmt"
+	"log"
+)
+
+// Ball returns the finite enumerable ball of the given radius around center,
+// { x | ‖x - center‖ ≤ radius } where ‖·‖ is the Norm n.
+func (s IntTupleSet) Ball(center Elem, radius int, n Norm) IntTupleBall {
+	c := center.(IntTuple)
+	if c.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: c.Size(), Dim2: s.Size()})
+	}
+	n.checkDim(c.Size())
+	return IntTupleBall{Set: s, center: c.Clone(), radius: radius, norm: n}
+}
+
+// IntTupleBall is a finite subset of IntTuple within
+// a fixed distance of a center, which can be enumerated.
+//
+// The ball is empty if the radius is negative.
+type IntTupleBall struct {
+	Set
+	center IntTuple
+	radius int
+	norm   Norm
+}
+
+// Center returns a copy of the center of the ball.
+func (b IntTupleBall) Center() IntTuple {
+	return b.center.Clone()
+}
+
+// Radius returns the radius of the ball.
+func (b IntTupleBall) Radius() int {
+	return b.radius
+}
+
+// Norm returns the norm which defines the ball.
+func (b IntTupleBall) Norm() Norm {
+	return b.norm
+}
+
+// IsIn returns true if x ∈ b.
+func (b IntTupleBall) IsIn(x Elem) bool {
+	xElem, ok := x.(IntTuple)
+	if !ok || xElem.Size() != b.center.Size() {
+		return false
+	}
+	return b.norm.Dist(xElem, b.center) <= b.radius
+}
+
+func (b IntTupleBall) superset() Set {
+	return b.Set
+}
+
+// Name returns the description of the subset.
+func (b IntTupleBall) Name() string {
+	return fmt.Sprintf("B%s(%s,%d)", b.norm.Name(), b.center, b.radius)
+}
+
+// Bounds returns the smallest interval containing the ball.
+//
+// Bounds is only defined for a non-empty ball.
+func (b IntTupleBall) Bounds() IntTupleInterval {
+	if b.radius < 0 {
+		log.Fatalf("cannot bound %s: ball is empty", b.Name())
+	}
+	lo, hi := make(IntTuple, b.center.Size()), make(IntTuple, b.center.Size())
+	for i := range b.center {
+		r := b.norm.bound(i, b.radius)
+		lo[i], hi[i] = b.center[i]-r, b.center[i]+r
+	}
+	return IntTupleInterval{Set: b.Set, lo: lo, hi: hi}
+}
+
+// Cardinality returns the number of IntTuples in the ball.
+func (b IntTupleBall) Cardinality() int {
+	if b.radius < 0 {
+		return 0
+	}
+	size := b.center.Size()
+	if b.norm.kind == lInfNorm {
+		card := 1
+		for i := 0; i < size; i++ {
+			card *= 2*b.norm.bound(i, b.radius) + 1
+		}
+		return card
+	}
+	// count[r] is the number of points of the remaining
+	// coordinates with partial norm ≤ r, built from the last coordinate.
+	count := make([]int, b.radius+1)
+	for r := range count {
+		count[r] = 1
+	}
+	for i := size - 1; i >= 0; i-- {
+		next := make([]int, b.radius+1)
+		for r := range next {
+			for v := b.norm.bound(i, r); v >= 0; v-- {
+				c := count[r-b.norm.cost(i, v)]
+				if v > 0 {
+					c *= 2 // ±v
+				}
+				next[r] += c
+			}
+		}
+		count = next
+	}
+	return count[b.radius]
+}
+
+// Enumerate creates an iterator for looping over the IntTuple in the ball.
+//
+// The iterator visits the ball in lexicographical order without visiting
+// the points of the bounding interval which lie outside the ball.
+func (b IntTupleBall) Enumerate() Nexter {
+	it := &IntTupleBallIter{
+		IntTupleBall: b,
+		curr:         make(IntTuple, b.center.Size()),
+		acc:          make([]int, b.center.Size()+1),
+	}
+	if b.radius >= 0 {
+		it.fill(0)
+		it.more = true
+	}
+	return it
+}
+
+// Slice returns ordered Elem in the ball as a slice.
+func (b IntTupleBall) Slice() []Elem {
+	s := make([]Elem, 0, b.Cardinality())
+	if b.radius < 0 {
+		return s
+	}
+	e := b.Enumerate()
+	for {
+		next, more := e.Next()
+		s = append(s, next)
+		if !more {
+			break
+		}
+	}
+	return s
+}
+
+// IntTupleBallIter is a IntTupleBall iterator.
+//
+// For an empty ball, Next returns a nil Elem.
+type IntTupleBallIter struct {
+	IntTupleBall
+	curr IntTuple
+	acc  []int // acc[i] is the partial norm of curr[:i] - center[:i].
+	more bool
+}
+
+// fill resets the coordinates from i onwards to the smallest values
+// in the ball given the coordinates before i.
+func (n *IntTupleBallIter) fill(i int) {
+	for ; i < n.curr.Size(); i++ {
+		r := n.norm.bound(i, n.norm.budget(n.radius, n.acc[i]))
+		n.curr[i] = n.center[i] - r
+		n.acc[i+1] = n.norm.combine(n.acc[i], n.norm.cost(i, -r))
+	}
+}
+
+// advance moves curr to the next IntTuple in the ball,
+// and returns false if curr is the last one.
+func (n *IntTupleBallIter) advance() bool {
+	for i := n.curr.Size() - 1; i >= 0; i-- {
+		r := n.norm.bound(i, n.norm.budget(n.radius, n.acc[i]))
+		if n.curr[i] < n.center[i]+r {
+			n.curr[i]++
+			n.acc[i+1] = n.norm.combine(n.acc[i], n.norm.cost(i, n.curr[i]-n.center[i]))
+			n.fill(i + 1)
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the next Elem in the ball, and indicates
+// if there are more elements in the ball with more.
+func (n *IntTupleBallIter) Next() (next Elem, more bool) {
+	if !n.more {
+		return nil, false
+	}
+	curr := n.curr.Clone()
+	n.more = n.advance()
+	return curr, n.more
+}
@@ -0,0 +1,86 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestBall(t *testing.T) {
+	norms := []Norm{L1, L2Sq, LInf, Weighted(L1, 2, 1, 3), Weighted(L2Sq, 1, 3, 2), Weighted(LInf, 1, 2, 2)}
+	s := NewIntTuple(3)
+	center := s.Tuple(1, -1, 2)
+	for _, n := range norms {
+		for r := 0; r <= 6; r++ {
+			b := s.Ball(center, r, n)
+			// Brute force: filter the bounding interval.
+			var want []Elem
+			for _, x := range b.Bounds().Slice() {
+				if n.Dist(x, center) <= r {
+					want = append(want, x)
+				}
+			}
+			got := b.Slice()
+			if len(want) != len(got) {
+				t.Errorf("%s: expected %d Elems but got %d", b.Name(), len(want), len(got))
+				continue
+			}
+			for i := range want {
+				if want[i].Compare(got[i]) != 0 {
+					t.Errorf("%s: expected %s at %d but got %s", b.Name(), want[i], i, got[i])
+				}
+				if !b.IsIn(got[i]) {
+					t.Errorf("%s: %s should be in the ball", b.Name(), got[i])
+				}
+			}
+			if w, g := len(want), b.Cardinality(); w != g {
+				t.Errorf("%s: expected cardinality %d but got %d", b.Name(), w, g)
+			}
+		}
+	}
+}
+
+func TestBallCardinality(t *testing.T) {
+	s := NewIntTuple(2)
+	tests := []struct {
+		norm   Norm
+		radius int
+		want   int
+	}{
+		{L1, 1, 5},
+		{L1, 2, 13},
+		{L2Sq, 4, 13},
+		{L2Sq, 5, 21},
+		{LInf, 1, 9},
+		{LInf, -1, 0},
+	}
+	for _, tt := range tests {
+		b := s.Ball(s.Identity(), tt.radius, tt.norm)
+		if got := b.Cardinality(); tt.want != got {
+			t.Errorf("%s: expected cardinality %d but got %d", b.Name(), tt.want, got)
+		}
+	}
+}
+
+func TestBallMembership(t *testing.T) {
+	s := NewIntTuple(2)
+	b := s.Ball(s.Tuple(0, 0), 2, L1)
+	if v := s.Tuple(1, 1); !b.IsIn(v) {
+		t.Errorf("%s should be in the ball %s", v, b.Name())
+	}
+	if v := s.Tuple(2, 1); b.IsIn(v) {
+		t.Errorf("%s should not be in the ball %s", v, b.Name())
+	}
+	if v := NewIntTuple(3).Tuple(0, 0, 0); b.IsIn(v) {
+		t.Errorf("%s should not be in the ball %s", v, b.Name())
+	}
+}
+
+func TestBallEmpty(t *testing.T) {
+	s := NewIntTuple(2)
+	b := s.Ball(s.Tuple(0, 0), -1, L1)
+	if n, more := b.Enumerate().Next(); n != nil || more {
+		t.Errorf("expected empty ball to enumerate nothing but got %v", n)
+	}
+	if got := len(b.Slice()); got != 0 {
+		t.Errorf("expected empty slice but got %d Elems", got)
+	}
+}
@@ -45,3 +45,16 @@ func ExampleIntTupleSet_Interval_alternative() {
 	// (2,1)
 	// (2,2)
 }
+
+func ExampleFreeAbelianSet() {
+	// Formal sums of chemical species, e.g. the reaction 2H₂ + O₂ → 2H₂O
+	// as the difference of products and reactants.
+	s := set.NewFreeAbelian("H2", "O2", "H2O")
+	reactants := s.Sum(set.Term{Coef: 2, Sym: "H2"}, set.Term{Coef: 1, Sym: "O2"})
+	products := s.Sum(set.Term{Coef: 2, Sym: "H2O"})
+	fmt.Println(s.Name())
+	fmt.Println(s.Sub(products, reactants))
+	// Output:
+	// ℤ⟨H2,H2O,O2⟩
+	// -2·H2 + 2·H2O - O2
+}
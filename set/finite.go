@@ -0,0 +1,126 @@
+package set
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// FiniteSet is a finite subset of a Set, which can be modified and enumerated.
+//
+// Elems are stored by their Key, and enumerated in
+// ascending order of Elem.Compare.
+type FiniteSet struct {
+	Set
+	elems  map[string]Elem
+	sorted []Elem // sorted Elems, nil if not computed since last change.
+}
+
+// NewFiniteSet returns a new finite subset of s with the given Elems.
+func NewFiniteSet(s Set, elems ...Elem) *FiniteSet {
+	f := &FiniteSet{Set: s, elems: make(map[string]Elem, len(elems))}
+	for _, x := range elems {
+		f.Add(x)
+	}
+	return f
+}
+
+// Add adds x to the set, and returns true if x was not already in the set.
+//
+// x must be a member of the set the FiniteSet is a subset of.
+func (f *FiniteSet) Add(x Elem) bool {
+	if !f.Set.IsIn(x) {
+		log.Fatalf("cannot add %s to finite subset of %s: not a member", x, f.Set.Name())
+	}
+	k := Key(x)
+	if _, ok := f.elems[k]; ok {
+		return false
+	}
+	if t, ok := x.(IntTuple); ok {
+		x = t.Clone() // the caller may modify t after Add.
+	}
+	f.elems[k] = x
+	f.sorted = nil
+	return true
+}
+
+// Remove removes x from the set, and returns true if x was in the set.
+func (f *FiniteSet) Remove(x Elem) bool {
+	k := Key(x)
+	if _, ok := f.elems[k]; !ok {
+		return false
+	}
+	delete(f.elems, k)
+	f.sorted = nil
+	return true
+}
+
+// Len returns the number of Elems in the set.
+func (f *FiniteSet) Len() int {
+	return len(f.elems)
+}
+
+// Cardinality returns the number of Elems in the set, the same as Len.
+func (f *FiniteSet) Cardinality() int {
+	return f.Len()
+}
+
+// IsIn returns true if x ∈ f.
+func (f *FiniteSet) IsIn(x Elem) bool {
+	_, ok := f.elems[Key(x)]
+	return ok
+}
+
+func (f *FiniteSet) superset() Set {
+	return f.Set
+}
+
+// Name returns the description of the subset, e.g. {(1,2),(3,4)}.
+func (f *FiniteSet) Name() string {
+	elems := f.Slice()
+	names := make([]string, len(elems))
+	for i := range elems {
+		names[i] = elems[i].String()
+	}
+	return "{" + strings.Join(names, ",") + "}"
+}
+
+// Enumerate creates an iterator for looping over the Elems of the set
+// in ascending order.
+//
+// For an empty set, Next returns a nil Elem. The iterator is not
+// affected by changes to the set after Enumerate is called.
+func (f *FiniteSet) Enumerate() Nexter {
+	return &FiniteSetIter{elems: f.Slice()}
+}
+
+// Slice returns the Elems of the set as a slice in ascending order.
+func (f *FiniteSet) Slice() []Elem {
+	if f.sorted == nil {
+		f.sorted = make([]Elem, 0, len(f.elems))
+		for _, x := range f.elems {
+			f.sorted = append(f.sorted, x)
+		}
+		sort.Slice(f.sorted, func(i, j int) bool { return f.sorted[i].Compare(f.sorted[j]) < 0 })
+	}
+	s := make([]Elem, len(f.sorted))
+	copy(s, f.sorted)
+	return s
+}
+
+// FiniteSetIter is a FiniteSet iterator.
+type FiniteSetIter struct {
+	elems []Elem
+	pos   int
+}
+
+// Next returns the next Elem in the set, and indicates
+// if there are more elements in the set with more.
+func (n *FiniteSetIter) Next() (next Elem, more bool) {
+	if n.pos >= len(n.elems) {
+		return nil, false
+	}
+	next = n.elems[n.pos]
+	n.pos++
+	return next, n.pos < len(n.elems)
+}
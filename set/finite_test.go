@@ -0,0 +1,88 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestFiniteSet(t *testing.T) {
+	s := NewIntTuple(2)
+	f := NewFiniteSet(s, s.Tuple(3, 1), s.Tuple(1, 2), s.Tuple(1, 1))
+	if want, got := 3, f.Len(); want != got {
+		t.Errorf("expected %d Elems but got %d", want, got)
+	}
+	if f.Add(s.Tuple(1, 2)) {
+		t.Errorf("(1,2) should already be in %s", f.Name())
+	}
+	if !f.Add(s.Tuple(0, 5)) {
+		t.Errorf("(0,5) should not already be in %s", f.Name())
+	}
+	if !f.IsIn(IntTuple{1, 2}) {
+		t.Errorf("(1,2) should be in %s", f.Name())
+	}
+	if !f.Remove(s.Tuple(3, 1)) {
+		t.Errorf("(3,1) should be removed from %s", f.Name())
+	}
+	if f.Remove(s.Tuple(3, 1)) {
+		t.Errorf("(3,1) should not be in %s", f.Name())
+	}
+	if f.IsIn(s.Tuple(3, 1)) {
+		t.Errorf("(3,1) should not be in %s", f.Name())
+	}
+	if want, got := "{(0,5),(1,1),(1,2)}", f.Name(); want != got {
+		t.Errorf("expected name %s but got %s", want, got)
+	}
+	if want, got := "(0,0)", f.Identity().String(); want != got {
+		t.Errorf("expected identity %s but got %s", want, got)
+	}
+}
+
+func TestFiniteSetAddCopies(t *testing.T) {
+	s := NewIntTuple(2)
+	x := s.Tuple(1, 2)
+	f := NewFiniteSet(s, x)
+	x[0] = 3
+	if !f.IsIn(IntTuple{1, 2}) {
+		t.Errorf("(1,2) should still be in %s", f.Name())
+	}
+	if want, got := "{(1,2)}", f.Name(); want != got {
+		t.Errorf("expected name %s but got %s", want, got)
+	}
+}
+
+func TestFiniteSetEnumerate(t *testing.T) {
+	s := NewIntTuple(1)
+	f := NewFiniteSet(s)
+	if n, more := f.Enumerate().Next(); n != nil || more {
+		t.Errorf("expected empty set to enumerate nothing but got %v", n)
+	}
+	for _, v := range []int{5, -1, 3, 0} {
+		f.Add(s.Tuple(v))
+	}
+	want := []IntTuple{{-1}, {0}, {3}, {5}}
+	iter := f.Enumerate()
+	for i := range want {
+		n, more := iter.Next()
+		if want[i].Compare(n) != 0 {
+			t.Errorf("expected %s but got %s", want[i], n)
+		}
+		if w := i < len(want)-1; w != more {
+			t.Errorf("expected more=%t at %d but got %t", w, i, more)
+		}
+	}
+	for i, n := range f.Slice() {
+		if want[i].Compare(n) != 0 {
+			t.Errorf("expected %s but got %s", want[i], n)
+		}
+	}
+}
+
+func TestFiniteSetParseElem(t *testing.T) {
+	s := NewIntTuple(2)
+	f := NewFiniteSet(s, s.Tuple(1, 2))
+	if _, err := ParseElem(f, "(1,2)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseElem(f, "(2,1)"); err == nil {
+		t.Errorf("expected (2,1) not to be a member of %s", f.Name())
+	}
+}
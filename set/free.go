@@ -0,0 +1,372 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FreeAbelianSet is the free abelian group over a basis of symbols,
+// i.e. the set of finitely supported formal sums such as 3·a - 2·b.
+//
+// Symbols can be any comparable values, such as strings.
+// Symbols are ordered by kind, strings before integers before Elems
+// before other values, and then by value within each kind, so sums of
+// mixed symbols are canonical and different symbols which print the
+// same are never merged.
+type FreeAbelianSet struct {
+	basis map[interface{}]bool // nil if any symbol is allowed.
+	names []string             // sorted names of the basis.
+}
+
+// NewFreeAbelian returns a new free abelian group over the given basis symbols.
+//
+// If no basis symbols are given, any comparable symbol is allowed.
+func NewFreeAbelian(basis ...interface{}) FreeAbelianSet {
+	if len(basis) == 0 {
+		return FreeAbelianSet{}
+	}
+	s := FreeAbelianSet{basis: make(map[interface{}]bool, len(basis))}
+	syms := make([]interface{}, 0, len(basis))
+	for _, b := range basis {
+		if !s.basis[b] {
+			s.basis[b] = true
+			syms = append(syms, b)
+		}
+	}
+	sort.Slice(syms, func(i, j int) bool { return compareSymbols(syms[i], syms[j]) < 0 })
+	for _, sym := range syms {
+		s.names = append(s.names, fmt.Sprint(sym))
+	}
+	return s
+}
+
+// Term is a term n·sym of a FormalSum.
+type Term struct {
+	Coef int
+	Sym  interface{}
+}
+
+// FormalSum is an Elem of a FreeAbelianSet, stored sparsely as its nonzero
+// terms in ascending order of symbols.
+type FormalSum []Term
+
+// Sum returns the FormalSum of the terms, combining terms with the same symbol.
+//
+// The symbols must be in the basis of s.
+func (s FreeAbelianSet) Sum(terms ...Term) FormalSum {
+	coefs := make(map[interface{}]int, len(terms))
+	for _, t := range terms {
+		if !s.isBasis(t.Sym) {
+			log.Fatalf("cannot create formal sum of %s: %v is not in the basis", s.Name(), t.Sym)
+		}
+		coefs[t.Sym] += t.Coef
+	}
+	sum := make(FormalSum, 0, len(coefs))
+	for sym, c := range coefs {
+		if c != 0 {
+			sum = append(sum, Term{Coef: c, Sym: sym})
+		}
+	}
+	sort.Slice(sum, func(i, j int) bool { return compareSymbols(sum[i].Sym, sum[j].Sym) < 0 })
+	return sum
+}
+
+// Basis returns the basis element 1·sym.
+func (s FreeAbelianSet) Basis(sym interface{}) FormalSum {
+	return s.Sum(Term{Coef: 1, Sym: sym})
+}
+
+func (s FreeAbelianSet) isBasis(sym interface{}) bool {
+	return s.basis == nil || s.basis[sym]
+}
+
+// Identity returns the identity of the set, i.e. the empty sum 0.
+func (s FreeAbelianSet) Identity() Elem {
+	return FormalSum{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s FreeAbelianSet) IsIn(x Elem) bool {
+	xElem, ok := x.(FormalSum)
+	if !ok {
+		return false
+	}
+	for _, t := range xElem {
+		if !s.isBasis(t.Sym) {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the formal name of the set, e.g. ℤ⟨a,b⟩,
+// or ℤ⟨*⟩ if any symbol is allowed.
+func (s FreeAbelianSet) Name() string {
+	if s.basis == nil {
+		return "ℤ⟨*⟩"
+	}
+	return "ℤ⟨" + strings.Join(s.names, ",") + "⟩"
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s FreeAbelianSet) Add(x, y Elem) Elem {
+	return x.(FormalSum).add(y.(FormalSum), 1)
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s FreeAbelianSet) Inverse(x Elem) Elem {
+	return x.(FormalSum).Scale(-1)
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s FreeAbelianSet) Sub(x, y Elem) Elem {
+	return x.(FormalSum).add(y.(FormalSum), -1)
+}
+
+// Less returns x < y.
+func (s FreeAbelianSet) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// add returns e + k·x by merging the sorted terms.
+func (e FormalSum) add(x FormalSum, k int) FormalSum {
+	sum := make(FormalSum, 0, len(e)+len(x))
+	i, j := 0, 0
+	for i < len(e) || j < len(x) {
+		var cmp int
+		switch {
+		case i == len(e):
+			cmp = 1
+		case j == len(x):
+			cmp = -1
+		default:
+			cmp = compareSymbols(e[i].Sym, x[j].Sym)
+		}
+		switch {
+		case cmp < 0:
+			sum = append(sum, e[i])
+			i++
+		case cmp > 0:
+			sum = append(sum, Term{Coef: k * x[j].Coef, Sym: x[j].Sym})
+			j++
+		default:
+			if c := e[i].Coef + k*x[j].Coef; c != 0 {
+				sum = append(sum, Term{Coef: c, Sym: e[i].Sym})
+			}
+			i, j = i+1, j+1
+		}
+	}
+	return sum
+}
+
+// Scale returns n·e.
+func (e FormalSum) Scale(n int) FormalSum {
+	if n == 0 {
+		return FormalSum{}
+	}
+	sum := make(FormalSum, len(e))
+	for i := range e {
+		sum[i] = Term{Coef: n * e[i].Coef, Sym: e[i].Sym}
+	}
+	return sum
+}
+
+// Coef returns the coefficient of sym in e.
+func (e FormalSum) Coef(sym interface{}) int {
+	i := sort.Search(len(e), func(i int) bool { return compareSymbols(e[i].Sym, sym) >= 0 })
+	if i < len(e) && compareSymbols(e[i].Sym, sym) == 0 {
+		return e[i].Coef
+	}
+	return 0
+}
+
+// Support returns the symbols with nonzero coefficients in e, in ascending order.
+func (e FormalSum) Support() []interface{} {
+	syms := make([]interface{}, len(e))
+	for i := range e {
+		syms[i] = e[i].Sym
+	}
+	return syms
+}
+
+// Coords returns the coefficients of e for the given symbols as an IntTuple,
+// i.e. the image of e in ℤⁿ for the basis sym[0], ..., sym[n-1].
+func (e FormalSum) Coords(sym ...interface{}) IntTuple {
+	x := make(IntTuple, len(sym))
+	for i := range sym {
+		x[i] = e.Coef(sym[i])
+	}
+	return x
+}
+
+// String returns the canonical representation of e, e.g. 3·a - 2·b.
+// The terms are in ascending order of symbols, and the empty sum is 0.
+func (e FormalSum) String() string {
+	if len(e) == 0 {
+		return "0"
+	}
+	var buf strings.Builder
+	for i, t := range e {
+		c := t.Coef
+		switch {
+		case i == 0 && c < 0:
+			buf.WriteString("-")
+			c = -c
+		case i > 0 && c < 0:
+			buf.WriteString(" - ")
+			c = -c
+		case i > 0:
+			buf.WriteString(" + ")
+		}
+		if c != 1 {
+			buf.WriteString(fmt.Sprintf("%d·", c))
+		}
+		buf.WriteString(fmt.Sprint(t.Sym))
+	}
+	return buf.String()
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x.
+//
+// Formal sums are ordered lexicographically by their coefficients
+// in ascending order of symbols, which is compatible with +.
+func (e FormalSum) Compare(x Elem) int {
+	d := e.add(x.(FormalSum), -1)
+	if len(d) == 0 {
+		return 0
+	}
+	if d[0].Coef < 0 {
+		return -1
+	}
+	return 1
+}
+
+// Key returns the coefficients and the keys of the symbols
+// of the terms of e as a string.
+func (e FormalSum) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	var buf []byte
+	for _, t := range e {
+		buf = append(buf, tmp[:binary.PutVarint(tmp[:], int64(t.Coef))]...)
+		buf = appendKeyString(buf, symbolKey(t.Sym))
+	}
+	return string(buf)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of e.
+func (e FormalSum) Hash() uint64 {
+	return hashKey(e.Key())
+}
+
+// compareSymbols returns the order of the symbols a and b.
+//
+// Symbols are ranked by kind first, strings < ints < Elems < others,
+// so that the order is total when the kinds are mixed. Elems are then
+// ordered by type and by Compare, and other symbols by type, by their
+// fmt representation and then by value.
+func compareSymbols(a, b interface{}) int {
+	if c := compareInts(symbolRank(a), symbolRank(b)); c != 0 {
+		return c
+	}
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		return compareInts(a, b.(int))
+	}
+	if a == b {
+		return 0
+	}
+	if c := strings.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+		return c
+	}
+	if a, ok := a.(Elem); ok {
+		return a.Compare(b.(Elem))
+	}
+	if c := strings.Compare(fmt.Sprint(a), fmt.Sprint(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(symbolKey(a), symbolKey(b))
+}
+
+// symbolRank returns the rank of the kind of sym in the order of symbols.
+func symbolRank(sym interface{}) int {
+	switch sym.(type) {
+	case string:
+		return 0
+	case int:
+		return 1
+	case Elem:
+		return 2
+	}
+	return 3
+}
+
+// symbolKey returns a key of the comparable symbol sym which is distinct
+// for symbols which are not ==, unlike the fmt representation, e.g.
+// for structs with interface fields holding 1 and "1".
+// The key includes the type of sym, e.g. int and int32 hold different 1s.
+func symbolKey(sym interface{}) string {
+	return string(appendSymbolKey(nil, reflect.ValueOf(&sym).Elem()))
+}
+
+func appendSymbolKey(buf []byte, v reflect.Value) []byte {
+	if !v.IsValid() {
+		return append(buf, "nil"...)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.AppendBool(buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(buf, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(buf, v.Float(), 'g', -1, 64)
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		buf = strconv.AppendFloat(append(buf, '('), real(c), 'g', -1, 64)
+		return append(strconv.AppendFloat(append(buf, ','), imag(c), 'g', -1, 64), ')')
+	case reflect.String:
+		return strconv.AppendQuote(buf, v.String())
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return strconv.AppendUint(append(buf, '@'), uint64(v.Pointer()), 16)
+	case reflect.Interface:
+		if v.IsNil() {
+			return append(buf, "nil"...)
+		}
+		t := v.Elem().Type() // dynamic type, e.g. int and int32 hold different 1s.
+		buf = append(buf, t.PkgPath()+"."+t.String()+"("...)
+		return append(appendSymbolKey(buf, v.Elem()), ')')
+	case reflect.Array, reflect.Struct:
+		buf = append(buf, v.Type().PkgPath()+"."+v.Type().String()+"{"...)
+		n := v.Len
+		field := v.Index
+		if v.Kind() == reflect.Struct {
+			n, field = v.NumField, v.Field
+		}
+		for i := 0; i < n(); i++ {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendSymbolKey(buf, field(i))
+		}
+		return append(buf, '}')
+	}
+	return append(buf, fmt.Sprintf("%#v", v)...)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,152 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestFormalSum(t *testing.T) {
+	s := NewFreeAbelian()
+	x := s.Sum(Term{3, "a"}, Term{-2, "b"})
+	y := s.Sum(Term{1, "b"}, Term{-3, "a"}, Term{5, "c"}, Term{1, "b"})
+	if want, got := "3·a - 2·b", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "-3·a + 2·b + 5·c", y.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	z := s.Add(x, y)
+	if want, got := "5·c", z.String(); want != got {
+		t.Errorf("expected %s + %s = %s but got %s", x, y, want, got)
+	}
+	if want, got := "-3·a + 2·b", s.Inverse(x).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "0", s.Add(x, s.Inverse(x)).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if s.Add(x, s.Identity()).Compare(x) != 0 {
+		t.Errorf("expected %s + 0 = %s", x, x)
+	}
+	if want, got := "a - b", s.Sum(Term{-1, "b"}, Term{1, "a"}).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "6·a - 4·b", x.Scale(2).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestFormalSumCompare(t *testing.T) {
+	s := NewFreeAbelian()
+	a, b := s.Basis("a"), s.Basis("b")
+	if a.Compare(b) <= 0 {
+		t.Errorf("expected %s > %s", a, b)
+	}
+	if s.Identity().Compare(b) >= 0 {
+		t.Errorf("expected 0 < %s", b)
+	}
+	if s.Add(a, b).Compare(s.Add(b, a)) != 0 {
+		t.Errorf("expected a + b = b + a")
+	}
+	// Compatible with +.
+	c := s.Sum(Term{-7, "c"})
+	if s.Add(a, c).Compare(s.Add(b, c)) <= 0 {
+		t.Errorf("expected a + c > b + c")
+	}
+}
+
+func TestFormalSumSupport(t *testing.T) {
+	s := NewFreeAbelian()
+	x := s.Sum(Term{2, "y"}, Term{0, "z"}, Term{-1, "x"})
+	support := x.Support()
+	if len(support) != 2 || support[0] != "x" || support[1] != "y" {
+		t.Errorf("expected support [x y] but got %v", support)
+	}
+	if want, got := 2, x.Coef("y"); want != got {
+		t.Errorf("expected coefficient %d but got %d", want, got)
+	}
+	if want, got := 0, x.Coef("z"); want != got {
+		t.Errorf("expected coefficient %d but got %d", want, got)
+	}
+	if want, got := (IntTuple{-1, 2, 0}), x.Coords("x", "y", "z"); want.Compare(got) != 0 {
+		t.Errorf("expected coordinates %s but got %s", want, got)
+	}
+}
+
+func TestFreeAbelianBasis(t *testing.T) {
+	s := NewFreeAbelian("b", "a", "a")
+	if want, got := "ℤ⟨a,b⟩", s.Name(); want != got {
+		t.Errorf("expected name %s but got %s", want, got)
+	}
+	if !s.IsIn(s.Basis("a")) {
+		t.Errorf("a should be in %s", s.Name())
+	}
+	if s.IsIn(NewFreeAbelian().Basis("c")) {
+		t.Errorf("c should not be in %s", s.Name())
+	}
+	if s.IsIn(IntTuple{1}) {
+		t.Errorf("1 should not be in %s", s.Name())
+	}
+	n := NewFreeAbelian(2, 10, 1)
+	if want, got := "2 - 10", n.Sub(n.Basis(2), n.Basis(10)).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+// pair is a symbol type whose fmt representation is not injective.
+type pair struct{ a, b interface{} }
+
+func TestFreeAbelianSamePrint(t *testing.T) {
+	s := NewFreeAbelian()
+	x, y, z := pair{1, "1"}, pair{"1", 1}, pair{int32(1), "1"}
+	d := s.Sub(s.Basis(x), s.Basis(y)).(FormalSum)
+	if want, got := 2, len(d); want != got {
+		t.Errorf("expected %d terms in %v but got %d", want, d, got)
+	}
+	if want, got := -1, d.Coef(y); want != got {
+		t.Errorf("expected coefficient %d of %#v but got %d", want, y, got)
+	}
+	if compareSymbols(x, z) == 0 || compareSymbols(x, z) != -compareSymbols(z, x) {
+		t.Errorf("expected %#v and %#v to be ordered", x, z)
+	}
+	if want, got := 3, len(s.Sum(Term{Coef: 1, Sym: x}, Term{Coef: 1, Sym: y}, Term{Coef: 1, Sym: z}, Term{Coef: 1, Sym: x})); want != got {
+		t.Errorf("expected %d distinct terms but got %d", want, got)
+	}
+}
+
+func TestFreeAbelianMixedSymbols(t *testing.T) {
+	s := NewFreeAbelian()
+	syms := []interface{}{2, 10, "15", "2", Int2{1, 2}, ModInt(3), pair{1, "1"}}
+	want := s.Sum(termsOf(syms...)...)
+	if got := want.String(); got != "15 + 2 + 2 + 10 + (1,2) + 3 + {1 1}" {
+		t.Errorf("expected symbols ordered by kind but got %s", got)
+	}
+	// Every rotation and reversal of the symbols gives the same sum.
+	for i := range syms {
+		rot := append(append([]interface{}{}, syms[i:]...), syms[:i]...)
+		rev := make([]interface{}, len(rot))
+		for j := range rot {
+			rev[len(rot)-1-j] = rot[j]
+		}
+		for _, order := range [][]interface{}{rot, rev} {
+			x := s.Sum(termsOf(order...)...)
+			if want.Compare(x) != 0 || want.String() != x.String() {
+				t.Errorf("expected %v but got %v", want, x)
+			}
+			if d := s.Sub(x, want).(FormalSum); len(d) != 0 {
+				t.Errorf("expected x - x = 0 but got %v", d)
+			}
+		}
+	}
+	if d := s.Add(want, s.Inverse(want)).(FormalSum); d.String() != "0" {
+		t.Errorf("expected x + -x = 0 but got %v", d)
+	}
+}
+
+// termsOf returns the terms 1·sym of the symbols.
+func termsOf(syms ...interface{}) []Term {
+	terms := make([]Term, len(syms))
+	for i, sym := range syms {
+		terms[i] = Term{Coef: 1, Sym: sym}
+	}
+	return terms
+}
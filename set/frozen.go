@@ -0,0 +1,82 @@
+package set
+
+import (
+	"encoding/binary"
+	"log"
+)
+
+// FrozenIntTuple is an immutable representation of an IntTuple.
+//
+// The coordinates are stored in a string, so a FrozenIntTuple cannot be
+// modified, can be shared across goroutines without copying, and is
+// comparable with == so it can be used directly as a map key.
+//
+// The zero value is the empty tuple ().
+type FrozenIntTuple struct {
+	coords string // coordinates as 8-byte big-endian integers.
+}
+
+// Freeze returns the immutable representation of e.
+func (e IntTuple) Freeze() FrozenIntTuple {
+	buf := make([]byte, 8*len(e))
+	for i, v := range e {
+		binary.BigEndian.PutUint64(buf[8*i:], uint64(v))
+	}
+	return FrozenIntTuple{coords: string(buf)}
+}
+
+// IntTuple returns a new IntTuple with the coordinates of f.
+func (f FrozenIntTuple) IntTuple() IntTuple {
+	x := make(IntTuple, f.Size())
+	for i := range x {
+		x[i] = f.At(i)
+	}
+	return x
+}
+
+// Size returns the tuple size of f.
+func (f FrozenIntTuple) Size() int {
+	return len(f.coords) / 8
+}
+
+// At returns the i-th coordinate of f.
+func (f FrozenIntTuple) At(i int) int {
+	if i < 0 || i >= f.Size() {
+		log.Fatalf("coordinate %d out of range of tuple/%d", i, f.Size())
+	}
+	var v uint64
+	for _, b := range []byte(f.coords[8*i : 8*i+8]) {
+		v = v<<8 | uint64(b)
+	}
+	return int(v)
+}
+
+// String returns the same representation as the String of the IntTuple.
+func (f FrozenIntTuple) String() string {
+	return f.IntTuple().String()
+}
+
+// Compare returns 0 if f == x, -ve int if f < x, +ve int if f > x,
+// comparing lexicographically as the IntTuple.
+func (f FrozenIntTuple) Compare(x Elem) int {
+	tuple := x.(FrozenIntTuple)
+	for i := 0; i < f.Size(); i++ {
+		if v, w := f.At(i), tuple.At(i); v < w {
+			return -1
+		} else if v > w {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Key returns the internal representation of f, which is unique
+// for each tuple.
+func (f FrozenIntTuple) Key() string {
+	return f.coords
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of f.
+func (f FrozenIntTuple) Hash() uint64 {
+	return hashKey(f.Key())
+}
@@ -0,0 +1,50 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestFrozenIntTuple(t *testing.T) {
+	x := IntTuple{-1, 0, 1 << 40}
+	f := x.Freeze()
+	x[0] = 7
+	if want, got := "(-1,0,1099511627776)", f.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := 3, f.Size(); want != got {
+		t.Errorf("expected size %d but got %d", want, got)
+	}
+	y := f.IntTuple()
+	y[1] = 5
+	if want, got := 0, f.At(1); want != got {
+		t.Errorf("expected coordinate 1 to be %d but got %d", want, got)
+	}
+	if want, got := "()", (FrozenIntTuple{}).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestFrozenIntTupleCompare(t *testing.T) {
+	tuples := []IntTuple{{-2, 5}, {-1, -3}, {-1, 0}, {0, 0}, {3, -9}}
+	for i := range tuples {
+		for j := range tuples {
+			if want, got := tuples[i].Compare(tuples[j]), tuples[i].Freeze().Compare(tuples[j].Freeze()); want != got {
+				t.Errorf("expected %v compared with %v to be %d but got %d", tuples[i], tuples[j], want, got)
+			}
+		}
+	}
+}
+
+// Tests frozen tuples can be used as map keys.
+func TestFrozenIntTupleKey(t *testing.T) {
+	m := map[FrozenIntTuple]int{}
+	m[IntTuple{1, 2}.Freeze()]++
+	m[NewIntTuple(2).Tuple(1, 2).Freeze()]++
+	m[IntTuple{2, 1}.Freeze()]++
+	if want, got := 2, m[IntTuple{1, 2}.Freeze()]; want != got {
+		t.Errorf("expected count %d but got %d", want, got)
+	}
+	if want, got := 2, len(m); want != got {
+		t.Errorf("expected %d keys but got %d", want, got)
+	}
+}
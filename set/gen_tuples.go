@@ -0,0 +1,313 @@
+//go:build ignore
+// +build ignore
+
+// This program generates the fixed-dimension tuple types Int2 and Int3.
+// Run it with go generate in the set package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("tuple").Parse(`// Code generated by gen_tuples.go; DO NOT EDIT.
+
+package set
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// {{.Tuple}} is an Elem of {{.Set}}, a tuple of {{.N}} integers stored by value.
+//
+// {{.Tuple}} is the fixed-dimension equivalent of an IntTuple of size {{.N}},
+// which can be used without heap allocation or bounds checks.
+type {{.Tuple}} [{{.N}}]int
+
+// {{.Tuple}}Of returns the {{.Tuple}} with the coordinates of the IntTuple x,
+// which must have size {{.N}}.
+func {{.Tuple}}Of(x IntTuple) {{.Tuple}} {
+	if x.Size() != {{.N}} {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: {{.N}}})
+	}
+	var e {{.Tuple}}
+	copy(e[:], x)
+	return e
+}
+
+// IntTuple returns e as an IntTuple of size {{.N}}.
+func (e {{.Tuple}}) IntTuple() IntTuple {
+	x := make(IntTuple, {{.N}})
+	copy(x, e[:])
+	return x
+}
+
+// Size returns the tuple size of e.
+func (e {{.Tuple}}) Size() int {
+	return {{.N}}
+}
+
+// String returns the tuple representation of e, the same as IntTuple.String.
+func (e {{.Tuple}}) String() string {
+	var buf strings.Builder
+	buf.WriteRune('(')
+	for i := range e {
+		if i != 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(fmt.Sprintf("%d", e[i]))
+	}
+	buf.WriteRune(')')
+	return buf.String()
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x.
+func (e {{.Tuple}}) Compare(x Elem) int {
+	tuple := x.({{.Tuple}})
+	for i := range e {
+		if e[i] < tuple[i] {
+			return -1
+		} else if e[i] > tuple[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Key returns the same key as the equivalent IntTuple.
+func (e {{.Tuple}}) Key() string {
+	var buf [64]byte
+	return string(IntTuple(e[:]).appendBinary(buf[:0]))
+}
+
+// Hash returns the same hash as the equivalent IntTuple.
+func (e {{.Tuple}}) Hash() uint64 {
+	return hashKey(e.Key())
+}
+
+// {{.Set}} is the set ℤ^{{.N}} of {{.Tuple}}.
+type {{.Set}} struct{}
+
+// Tuple returns the {{.Tuple}} of the given coordinates.
+func (s {{.Set}}) Tuple({{range $i, $c := .Coords}}{{if $i}}, {{end}}{{$c}}{{end}} int) {{.Tuple}} {
+	return {{.Tuple}}{ {{- range $i, $c := .Coords}}{{if $i}}, {{end}}{{$c}}{{end -}} }
+}
+
+// Size returns the tuple size of the set.
+func (s {{.Set}}) Size() int {
+	return {{.N}}
+}
+
+// Identity returns the identity of the set, i.e. the zero tuple.
+func (s {{.Set}}) Identity() Elem {
+	return {{.Tuple}}{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s {{.Set}}) IsIn(x Elem) bool {
+	_, ok := x.({{.Tuple}})
+	return ok
+}
+
+// Name returns the formal name of the set.
+func (s {{.Set}}) Name() string {
+	return NewIntTuple({{.N}}).Name()
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s {{.Set}}) Add(x, y Elem) Elem {
+	return x.({{.Tuple}}).Add(y.({{.Tuple}}))
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s {{.Set}}) Sub(x, y Elem) Elem {
+	return x.({{.Tuple}}).Sub(y.({{.Tuple}}))
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s {{.Set}}) Inverse(x Elem) Elem {
+	return x.({{.Tuple}}).Neg()
+}
+
+// Less returns x < y.
+func (s {{.Set}}) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s {{.Set}}) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s {{.Set}}) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// Rank returns the torsion-free rank of the set, i.e. {{.N}}.
+func (s {{.Set}}) Rank() int {
+	return {{.N}}
+}
+
+// Interval returns a finite enumerable range, the box
+// { a | lo ≤ a ≤ hi } where ≤ is compared coordinate-wise.
+func (s {{.Set}}) Interval(lo, hi Elem) Enumerable {
+	return {{.Tuple}}Interval{lo: lo.({{.Tuple}}), hi: hi.({{.Tuple}})}
+}
+
+// Add returns e + x.
+func (e {{.Tuple}}) Add(x {{.Tuple}}) {{.Tuple}} {
+	for i := range e {
+		e[i] += x[i]
+	}
+	return e
+}
+
+// Sub returns e - x.
+func (e {{.Tuple}}) Sub(x {{.Tuple}}) {{.Tuple}} {
+	for i := range e {
+		e[i] -= x[i]
+	}
+	return e
+}
+
+// Neg returns -e.
+func (e {{.Tuple}}) Neg() {{.Tuple}} {
+	for i := range e {
+		e[i] = -e[i]
+	}
+	return e
+}
+
+// {{.Tuple}}Interval is a finite box of {{.Tuple}} which can be enumerated.
+type {{.Tuple}}Interval struct {
+	{{.Set}}
+	lo, hi {{.Tuple}}
+}
+
+// Lo returns the lower bound of the interval.
+func (r {{.Tuple}}Interval) Lo() {{.Tuple}} {
+	return r.lo
+}
+
+// Hi returns the upper bound of the interval.
+func (r {{.Tuple}}Interval) Hi() {{.Tuple}} {
+	return r.hi
+}
+
+// IsIn returns true if x ∈ r.
+func (r {{.Tuple}}Interval) IsIn(x Elem) bool {
+	xElem, ok := x.({{.Tuple}})
+	if !ok {
+		return false
+	}
+	for i := range xElem {
+		if xElem[i] < r.lo[i] || xElem[i] > r.hi[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the description of the subset.
+func (r {{.Tuple}}Interval) Name() string {
+	return fmt.Sprintf("%s≤..≤%s", r.lo, r.hi)
+}
+
+// Cardinality returns the number of {{.Tuple}} in the range.
+func (r {{.Tuple}}Interval) Cardinality() int {
+	card := 1
+	for i := range r.lo {
+		if r.hi[i] < r.lo[i] {
+			return 0
+		}
+		card *= r.hi[i] - r.lo[i] + 1
+	}
+	return card
+}
+
+// Enumerate creates an iterator for looping over the {{.Tuple}} in the range.
+// For an empty range, Next returns a nil Elem.
+func (r {{.Tuple}}Interval) Enumerate() Nexter {
+	if r.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	return &{{.Tuple}}Iter{ {{- .Tuple}}Interval: r, curr: r.lo}
+}
+
+// Slice returns ordered Elem in the range as a slice.
+func (r {{.Tuple}}Interval) Slice() []Elem {
+	card := r.Cardinality()
+	s := make([]Elem, 0, card)
+	if card == 0 {
+		return s
+	}
+	e := r.Enumerate()
+	for {
+		next, more := e.Next()
+		s = append(s, next)
+		if !more {
+			break
+		}
+	}
+	return s
+}
+
+// {{.Tuple}}Iter is a {{.Tuple}} iterator.
+type {{.Tuple}}Iter struct {
+	{{.Tuple}}Interval
+	curr {{.Tuple}}
+	done bool
+}
+
+// Next returns the next Elem in the range, and indicates
+// if there are more elements in the range with more.
+func (n *{{.Tuple}}Iter) Next() (next Elem, more bool) {
+	next = n.curr
+	if n.done {
+		return next, false
+	}
+	for i := len(n.curr) - 1; i >= 0; i-- {
+		if n.curr[i] < n.hi[i] {
+			n.curr[i]++
+			return next, true
+		}
+		n.curr[i] = n.lo[i]
+	}
+	n.curr, n.done = n.hi, true
+	return next, false
+}
+`))
+
+type tuple struct {
+	N      int
+	Tuple  string
+	Set    string
+	Coords []string
+}
+
+func main() {
+	for _, t := range []tuple{
+		{N: 2, Tuple: "Int2", Set: "Int2Set", Coords: []string{"x", "y"}},
+		{N: 3, Tuple: "Int3", Set: "Int3Set", Coords: []string{"x", "y", "z"}},
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, t); err != nil {
+			log.Fatal(err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			log.Fatalf("cannot format generated %s: %v", t.Tuple, err)
+		}
+		name := fmt.Sprintf("int%d.go", t.N)
+		if err := ioutil.WriteFile(name, src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
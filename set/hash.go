@@ -0,0 +1,67 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// Hasher is implemented by Elems which provide a stable key and hash.
+//
+// Key must return the same string for Elems which Compare equal,
+// so it can be used as a map key, and Hash must be a function of Key
+// which does not change between processes, so it can be used for sharding.
+type Hasher interface {
+	Key() string
+	Hash() uint64
+}
+
+// Key returns a string key of x for use as map key.
+//
+// If x does not implement Hasher, the key is the type of x and the
+// String of x, so Elems of different types which print the same have
+// different keys. Elems of the same type must then print differently
+// unless they Compare equal.
+func Key(x Elem) string {
+	if h, ok := x.(Hasher); ok {
+		return h.Key()
+	}
+	return string(appendKeyString(appendKeyString(nil, fmt.Sprintf("%T", x)), x.String()))
+}
+
+// Hash returns a stable 64-bit hash of x.
+//
+// If x does not implement Hasher, the hash is the FNV-1a hash of Key(x).
+func Hash(x Elem) uint64 {
+	if h, ok := x.(Hasher); ok {
+		return h.Hash()
+	}
+	return hashKey(Key(x))
+}
+
+// appendKeyString appends s to the key buf prefixed by its length,
+// so that the concatenation of keys is unambiguous.
+func appendKeyString(buf []byte, s string) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(len(s)))]...)
+	return append(buf, s...)
+}
+
+// hashKey returns the 64-bit FNV-1a hash of key.
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// Key returns the binary encoding of e as a string,
+// which is cheaper to compute than the String of e.
+func (e IntTuple) Key() string {
+	var buf [64]byte
+	return string(e.appendBinary(buf[:0]))
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of e.
+func (e IntTuple) Hash() uint64 {
+	return hashKey(e.Key())
+}
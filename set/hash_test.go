@@ -0,0 +1,94 @@
+package set
+
+import (
+	"strings"
+	"testing"
+)
+
+// stringElem is an Elem which does not implement Hasher.
+type stringElem string
+
+func (e stringElem) String() string { return string(e) }
+
+func (e stringElem) Compare(x Elem) int {
+	return strings.Compare(string(e), string(x.(stringElem)))
+}
+
+func TestIntTupleKey(t *testing.T) {
+	s := NewIntTuple(2)
+	if Key(s.Tuple(1, 2)) != Key(IntTuple{1, 2}) {
+		t.Errorf("expected equal tuples to have the same key")
+	}
+	seen := make(map[string]IntTuple)
+	for _, x := range s.Interval(s.Tuple(-3, -3), s.Tuple(3, 3)).Slice() {
+		k := Key(x)
+		if y, ok := seen[k]; ok {
+			t.Errorf("%s and %s have the same key %q", x, y, k)
+		}
+		seen[k] = x.(IntTuple)
+	}
+	if Key(IntTuple{1}) == Key(IntTuple{1, 0}) {
+		t.Errorf("expected tuples of different sizes to have different keys")
+	}
+}
+
+func TestIntTupleHash(t *testing.T) {
+	// The hash must be stable across processes.
+	tests := []struct {
+		x    IntTuple
+		want uint64
+	}{
+		{IntTuple{}, 0xaf63bd4c8601b7df},
+		{IntTuple{1, 2}, 0xeaa6d01875e4ec93},
+	}
+	for _, tt := range tests {
+		if got := Hash(tt.x); tt.want != got {
+			t.Errorf("expected hash of %s to be %#x but got %#x", tt.x, tt.want, got)
+		}
+	}
+}
+
+// otherElem is an Elem which prints the same as a stringElem.
+type otherElem string
+
+func (e otherElem) String() string { return string(e) }
+
+func (e otherElem) Compare(x Elem) int {
+	return strings.Compare(string(e), string(x.(otherElem)))
+}
+
+func TestKeyFallback(t *testing.T) {
+	if Key(stringElem("a")) != Key(stringElem("a")) || Key(stringElem("a")) == Key(stringElem("b")) {
+		t.Errorf("expected fallback keys to be equal only for equal Elems")
+	}
+	if Key(stringElem("a")) == Key(otherElem("a")) {
+		t.Errorf("expected Elems of different types which print the same to have different keys")
+	}
+	if Hash(stringElem("a")) != hashKey(Key(stringElem("a"))) {
+		t.Errorf("expected hash of fallback key")
+	}
+}
+
+func TestFormalSumKey(t *testing.T) {
+	s := NewFreeAbelian()
+	x, y := s.Basis(pair{1, "1"}), s.Basis(pair{"1", 1})
+	if x.String() != y.String() || Key(x) == Key(y) {
+		t.Errorf("expected %v and %v to print the same but have different keys", x, y)
+	}
+	if want, got := Key(s.Sum(termsOf(2, "a")...)), Key(s.Sum(termsOf("a", 2)...)); want != got {
+		t.Errorf("expected key %q but got %q", want, got)
+	}
+	f := NewFiniteSet(s, x, y)
+	if want, got := 2, f.Len(); want != got {
+		t.Errorf("expected %d Elems but got %d", want, got)
+	}
+}
+
+func TestModIntKey(t *testing.T) {
+	if Key(ModInt(1)) == Key(ModInt(2)) || Key(ModInt(1)) == Key(stringElem("1")) {
+		t.Errorf("expected distinct keys of residues")
+	}
+	if Hash(ModInt(3)) != hashKey(ModInt(3).Key()) {
+		t.Errorf("expected hash of key")
+	}
+}
@@ -0,0 +1,269 @@
+// Code generated by gen_tuples.go; DO NOT EDIT.
+
+package set
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Int2 is an Elem of Int2Set, a tuple of 2 integers stored by value.
+//
+// Int2 is the fixed-dimension equivalent of an IntTuple of size 2,
+// which can be used without heap allocation or bounds checks.
+type Int2 [2]int
+
+// Int2Of returns the Int2 with the coordinates of the IntTuple x,
+// which must have size 2.
+func Int2Of(x IntTuple) Int2 {
+	if x.Size() != 2 {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: 2})
+	}
+	var e Int2
+	copy(e[:], x)
+	return e
+}
+
+// IntTuple returns e as an IntTuple of size 2.
+func (e Int2) IntTuple() IntTuple {
+	x := make(IntTuple, 2)
+	copy(x, e[:])
+	return x
+}
+
+// Size returns the tuple size of e.
+func (e Int2) Size() int {
+	return 2
+}
+
+// String returns the tuple representation of e, the same as IntTuple.String.
+func (e Int2) String() string {
+	var buf strings.Builder
+	buf.WriteRune('(')
+	for i := range e {
+		if i != 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(fmt.Sprintf("%d", e[i]))
+	}
+	buf.WriteRune(')')
+	return buf.String()
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x.
+func (e Int2) Compare(x Elem) int {
+	tuple := x.(Int2)
+	for i := range e {
+		if e[i] < tuple[i] {
+			return -1
+		} else if e[i] > tuple[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Key returns the same key as the equivalent IntTuple.
+func (e Int2) Key() string {
+	var buf [64]byte
+	return string(IntTuple(e[:]).appendBinary(buf[:0]))
+}
+
+// Hash returns the same hash as the equivalent IntTuple.
+func (e Int2) Hash() uint64 {
+	return hashKey(e.Key())
+}
+
+// Int2Set is the set ℤ^2 of Int2.
+type Int2Set struct{}
+
+// Tuple returns the Int2 of the given coordinates.
+func (s Int2Set) Tuple(x, y int) Int2 {
+	return Int2{x, y}
+}
+
+// Size returns the tuple size of the set.
+func (s Int2Set) Size() int {
+	return 2
+}
+
+// Identity returns the identity of the set, i.e. the zero tuple.
+func (s Int2Set) Identity() Elem {
+	return Int2{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s Int2Set) IsIn(x Elem) bool {
+	_, ok := x.(Int2)
+	return ok
+}
+
+// Name returns the formal name of the set.
+func (s Int2Set) Name() string {
+	return NewIntTuple(2).Name()
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s Int2Set) Add(x, y Elem) Elem {
+	return x.(Int2).Add(y.(Int2))
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s Int2Set) Sub(x, y Elem) Elem {
+	return x.(Int2).Sub(y.(Int2))
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s Int2Set) Inverse(x Elem) Elem {
+	return x.(Int2).Neg()
+}
+
+// Less returns x < y.
+func (s Int2Set) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s Int2Set) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s Int2Set) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// Rank returns the torsion-free rank of the set, i.e. 2.
+func (s Int2Set) Rank() int {
+	return 2
+}
+
+// Interval returns a finite enumerable range, the box
+// { a | lo ≤ a ≤ hi } where ≤ is compared coordinate-wise.
+func (s Int2Set) Interval(lo, hi Elem) Enumerable {
+	return Int2Interval{lo: lo.(Int2), hi: hi.(Int2)}
+}
+
+// Add returns e + x.
+func (e Int2) Add(x Int2) Int2 {
+	for i := range e {
+		e[i] += x[i]
+	}
+	return e
+}
+
+// Sub returns e - x.
+func (e Int2) Sub(x Int2) Int2 {
+	for i := range e {
+		e[i] -= x[i]
+	}
+	return e
+}
+
+// Neg returns -e.
+func (e Int2) Neg() Int2 {
+	for i := range e {
+		e[i] = -e[i]
+	}
+	return e
+}
+
+// Int2Interval is a finite box of Int2 which can be enumerated.
+type Int2Interval struct {
+	Int2Set
+	lo, hi Int2
+}
+
+// Lo returns the lower bound of the interval.
+func (r Int2Interval) Lo() Int2 {
+	return r.lo
+}
+
+// Hi returns the upper bound of the interval.
+func (r Int2Interval) Hi() Int2 {
+	return r.hi
+}
+
+// IsIn returns true if x ∈ r.
+func (r Int2Interval) IsIn(x Elem) bool {
+	xElem, ok := x.(Int2)
+	if !ok {
+		return false
+	}
+	for i := range xElem {
+		if xElem[i] < r.lo[i] || xElem[i] > r.hi[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the description of the subset.
+func (r Int2Interval) Name() string {
+	return fmt.Sprintf("%s≤..≤%s", r.lo, r.hi)
+}
+
+// Cardinality returns the number of Int2 in the range.
+func (r Int2Interval) Cardinality() int {
+	card := 1
+	for i := range r.lo {
+		if r.hi[i] < r.lo[i] {
+			return 0
+		}
+		card *= r.hi[i] - r.lo[i] + 1
+	}
+	return card
+}
+
+// Enumerate creates an iterator for looping over the Int2 in the range.
+// For an empty range, Next returns a nil Elem.
+func (r Int2Interval) Enumerate() Nexter {
+	if r.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	return &Int2Iter{Int2Interval: r, curr: r.lo}
+}
+
+// Slice returns ordered Elem in the range as a slice.
+func (r Int2Interval) Slice() []Elem {
+	card := r.Cardinality()
+	s := make([]Elem, 0, card)
+	if card == 0 {
+		return s
+	}
+	e := r.Enumerate()
+	for {
+		next, more := e.Next()
+		s = append(s, next)
+		if !more {
+			break
+		}
+	}
+	return s
+}
+
+// Int2Iter is a Int2 iterator.
+type Int2Iter struct {
+	Int2Interval
+	curr Int2
+	done bool
+}
+
+// Next returns the next Elem in the range, and indicates
+// if there are more elements in the range with more.
+func (n *Int2Iter) Next() (next Elem, more bool) {
+	next = n.curr
+	if n.done {
+		return next, false
+	}
+	for i := len(n.curr) - 1; i >= 0; i-- {
+		if n.curr[i] < n.hi[i] {
+			n.curr[i]++
+			return next, true
+		}
+		n.curr[i] = n.lo[i]
+	}
+	n.curr, n.done = n.hi, true
+	return next, false
+}
@@ -0,0 +1,88 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestInt2(t *testing.T) {
+	var s Int2Set
+	x, y := s.Tuple(1, 2), s.Tuple(3, -4)
+	if want, got := "(4,-2)", s.Add(x, y).(Int2).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "(-2,6)", s.Sub(x, y).(Int2).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "(-1,-2)", s.Inverse(x).(Int2).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := s.Identity(), s.Add(x, s.Inverse(x)); want.Compare(got) != 0 {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+	if !s.Less(x, y) || s.Less(y, x) || !s.LessEqual(x, x) {
+		t.Errorf("expected %v < %v", x, y)
+	}
+	if want, got := NewIntTuple(2).Name(), s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if s.IsIn(IntTuple{1, 2}) {
+		t.Errorf("IntTuple should not be a member of %s", s.Name())
+	}
+}
+
+func TestInt2IntTuple(t *testing.T) {
+	x := IntTuple{5, -7}
+	e := Int2Of(x)
+	if want, got := x.String(), e.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := x, e.IntTuple(); want.Compare(got) != 0 {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+	if want, got := x.Key(), e.Key(); want != got {
+		t.Errorf("expected key %q but got %q", want, got)
+	}
+	if want, got := x.Hash(), e.Hash(); want != got {
+		t.Errorf("expected hash %#x but got %#x", want, got)
+	}
+}
+
+func TestInt2Interval(t *testing.T) {
+	var s Int2Set
+	r := s.Interval(s.Tuple(-1, 0), s.Tuple(1, 2)).(Int2Interval)
+	want := NewIntTuple(2).Interval(IntTuple{-1, 0}, IntTuple{1, 2}).Slice()
+	got := r.Slice()
+	if len(want) != len(got) || len(got) != r.Cardinality() {
+		t.Fatalf("expected %d elements but got %d (cardinality %d)", len(want), len(got), r.Cardinality())
+	}
+	for i := range want {
+		if want[i].Compare(got[i].(Int2).IntTuple()) != 0 {
+			t.Errorf("expected element %d to be %v but got %v", i, want[i], got[i])
+		}
+		if !r.IsIn(got[i]) {
+			t.Errorf("%v should be member of %s", got[i], r.Name())
+		}
+	}
+	if r.IsIn(s.Tuple(0, 3)) {
+		t.Errorf("(0,3) should not be member of %s", r.Name())
+	}
+}
+
+func TestInt2EmptyInterval(t *testing.T) {
+	var s Int2Set
+	r := s.Interval(s.Tuple(2, 0), s.Tuple(1, 0)).(Int2Interval)
+	if want, got := 0, len(r.Slice()); want != got {
+		t.Errorf("expected %d elements in %s but got %v", want, r.Name(), r.Slice())
+	}
+	if next, more := r.Enumerate().Next(); next != nil || more {
+		t.Errorf("expected empty iterator of %s but got %v, %t", r.Name(), next, more)
+	}
+}
+
+func BenchmarkInt2Add(b *testing.B) {
+	x, y := Int2{1, 2}, Int2{3, 4}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x = x.Add(y)
+	}
+}
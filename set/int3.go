@@ -0,0 +1,269 @@
+// Code generated by gen_tuples.go; DO NOT EDIT.
+
+package set
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Int3 is an Elem of Int3Set, a tuple of 3 integers stored by value.
+//
+// Int3 is the fixed-dimension equivalent of an IntTuple of size 3,
+// which can be used without heap allocation or bounds checks.
+type Int3 [3]int
+
+// Int3Of returns the Int3 with the coordinates of the IntTuple x,
+// which must have size 3.
+func Int3Of(x IntTuple) Int3 {
+	if x.Size() != 3 {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: 3})
+	}
+	var e Int3
+	copy(e[:], x)
+	return e
+}
+
+// IntTuple returns e as an IntTuple of size 3.
+func (e Int3) IntTuple() IntTuple {
+	x := make(IntTuple, 3)
+	copy(x, e[:])
+	return x
+}
+
+// Size returns the tuple size of e.
+func (e Int3) Size() int {
+	return 3
+}
+
+// String returns the tuple representation of e, the same as IntTuple.String.
+func (e Int3) String() string {
+	var buf strings.Builder
+	buf.WriteRune('(')
+	for i := range e {
+		if i != 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(fmt.Sprintf("%d", e[i]))
+	}
+	buf.WriteRune(')')
+	return buf.String()
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x.
+func (e Int3) Compare(x Elem) int {
+	tuple := x.(Int3)
+	for i := range e {
+		if e[i] < tuple[i] {
+			return -1
+		} else if e[i] > tuple[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Key returns the same key as the equivalent IntTuple.
+func (e Int3) Key() string {
+	var buf [64]byte
+	return string(IntTuple(e[:]).appendBinary(buf[:0]))
+}
+
+// Hash returns the same hash as the equivalent IntTuple.
+func (e Int3) Hash() uint64 {
+	return hashKey(e.Key())
+}
+
+// Int3Set is the set ℤ^3 of Int3.
+type Int3Set struct{}
+
+// Tuple returns the Int3 of the given coordinates.
+func (s Int3Set) Tuple(x, y, z int) Int3 {
+	return Int3{x, y, z}
+}
+
+// Size returns the tuple size of the set.
+func (s Int3Set) Size() int {
+	return 3
+}
+
+// Identity returns the identity of the set, i.e. the zero tuple.
+func (s Int3Set) Identity() Elem {
+	return Int3{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s Int3Set) IsIn(x Elem) bool {
+	_, ok := x.(Int3)
+	return ok
+}
+
+// Name returns the formal name of the set.
+func (s Int3Set) Name() string {
+	return NewIntTuple(3).Name()
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s Int3Set) Add(x, y Elem) Elem {
+	return x.(Int3).Add(y.(Int3))
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s Int3Set) Sub(x, y Elem) Elem {
+	return x.(Int3).Sub(y.(Int3))
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s Int3Set) Inverse(x Elem) Elem {
+	return x.(Int3).Neg()
+}
+
+// Less returns x < y.
+func (s Int3Set) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s Int3Set) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s Int3Set) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// Rank returns the torsion-free rank of the set, i.e. 3.
+func (s Int3Set) Rank() int {
+	return 3
+}
+
+// Interval returns a finite enumerable range, the box
+// { a | lo ≤ a ≤ hi } where ≤ is compared coordinate-wise.
+func (s Int3Set) Interval(lo, hi Elem) Enumerable {
+	return Int3Interval{lo: lo.(Int3), hi: hi.(Int3)}
+}
+
+// Add returns e + x.
+func (e Int3) Add(x Int3) Int3 {
+	for i := range e {
+		e[i] += x[i]
+	}
+	return e
+}
+
+// Sub returns e - x.
+func (e Int3) Sub(x Int3) Int3 {
+	for i := range e {
+		e[i] -= x[i]
+	}
+	return e
+}
+
+// Neg returns -e.
+func (e Int3) Neg() Int3 {
+	for i := range e {
+		e[i] = -e[i]
+	}
+	return e
+}
+
+// Int3Interval is a finite box of Int3 which can be enumerated.
+type Int3Interval struct {
+	Int3Set
+	lo, hi Int3
+}
+
+// Lo returns the lower bound of the interval.
+func (r Int3Interval) Lo() Int3 {
+	return r.lo
+}
+
+// Hi returns the upper bound of the interval.
+func (r Int3Interval) Hi() Int3 {
+	return r.hi
+}
+
+// IsIn returns true if x ∈ r.
+func (r Int3Interval) IsIn(x Elem) bool {
+	xElem, ok := x.(Int3)
+	if !ok {
+		return false
+	}
+	for i := range xElem {
+		if xElem[i] < r.lo[i] || xElem[i] > r.hi[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the description of the subset.
+func (r Int3Interval) Name() string {
+	return fmt.Sprintf("%s≤..≤%s", r.lo, r.hi)
+}
+
+// Cardinality returns the number of Int3 in the range.
+func (r Int3Interval) Cardinality() int {
+	card := 1
+	for i := range r.lo {
+		if r.hi[i] < r.lo[i] {
+			return 0
+		}
+		card *= r.hi[i] - r.lo[i] + 1
+	}
+	return card
+}
+
+// Enumerate creates an iterator for looping over the Int3 in the range.
+// For an empty range, Next returns a nil Elem.
+func (r Int3Interval) Enumerate() Nexter {
+	if r.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	return &Int3Iter{Int3Interval: r, curr: r.lo}
+}
+
+// Slice returns ordered Elem in the range as a slice.
+func (r Int3Interval) Slice() []Elem {
+	card := r.Cardinality()
+	s := make([]Elem, 0, card)
+	if card == 0 {
+		return s
+	}
+	e := r.Enumerate()
+	for {
+		next, more := e.Next()
+		s = append(s, next)
+		if !more {
+			break
+		}
+	}
+	return s
+}
+
+// Int3Iter is a Int3 iterator.
+type Int3Iter struct {
+	Int3Interval
+	curr Int3
+	done bool
+}
+
+// Next returns the next Elem in the range, and indicates
+// if there are more elements in the range with more.
+func (n *Int3Iter) Next() (next Elem, more bool) {
+	next = n.curr
+	if n.done {
+		return next, false
+	}
+	for i := len(n.curr) - 1; i >= 0; i-- {
+		if n.curr[i] < n.hi[i] {
+			n.curr[i]++
+			return next, true
+		}
+		n.curr[i] = n.lo[i]
+	}
+	n.curr, n.done = n.hi, true
+	return next, false
+}
@@ -0,0 +1,55 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestInt3(t *testing.T) {
+	var s Int3Set
+	x, y := s.Tuple(1, 2, 3), s.Tuple(0, 0, 4)
+	if want, got := "(1,2,7)", s.Add(x, y).(Int3).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := 1, x.Compare(y); want != got {
+		t.Errorf("expected %v compared with %v to be %d but got %d", x, y, want, got)
+	}
+	if want, got := x, Int3Of(x.IntTuple()); want != got {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+}
+
+func TestInt3Enumerate(t *testing.T) {
+	var s Int3Set
+	r := s.Interval(s.Tuple(0, 0, 0), s.Tuple(1, 1, 1)).(Int3Interval)
+	var got []Elem
+	e := r.Enumerate()
+	for {
+		next, more := e.Next()
+		got = append(got, next)
+		if !more {
+			break
+		}
+	}
+	if want := 8; want != len(got) || want != r.Cardinality() {
+		t.Fatalf("expected %d elements but got %d (cardinality %d)", want, len(got), r.Cardinality())
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i-1].Compare(got[i]) >= 0 {
+			t.Errorf("expected %v < %v", got[i-1], got[i])
+		}
+	}
+	if want, got := r.Hi(), got[len(got)-1]; want != got {
+		t.Errorf("expected last element %v but got %v", want, got)
+	}
+}
+
+func TestInt3EmptyInterval(t *testing.T) {
+	var s Int3Set
+	r := s.Interval(s.Tuple(0, 0, 0), s.Tuple(1, -1, 1)).(Int3Interval)
+	if want, got := 0, len(r.Slice()); want != got {
+		t.Errorf("expected %d elements in %s but got %v", want, r.Name(), r.Slice())
+	}
+	if next, more := r.Enumerate().Next(); next != nil || more {
+		t.Errorf("expected empty iterator of %s but got %v, %t", r.Name(), next, more)
+	}
+}
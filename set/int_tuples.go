@@ -31,7 +31,8 @@ func NewIntTuple(size int) IntTupleSet {
 }
 
 // Tuple is a variadic function to create a tuple from v,
-// a member of the set.
+// a member of the set. The tuple is a copy of v, so modifying
+// v afterwards does not change the tuple.
 //
 // The length of v must match tuple sizes in s, otherwise
 // it throws a runtime error.
@@ -39,7 +40,7 @@ func (s IntTupleSet) Tuple(v ...int) IntTuple {
 	if len(v) != s.Size() {
 		log.Fatalf("cannot create tuple/%d from %v: %v", s.Size(), v, MismatchDimErr{len(v), s.Size()})
 	}
-	return IntTuple(v)
+	return IntTuple(v).Clone()
 }
 
 // Identity returns the identity of the set.
@@ -97,6 +98,81 @@ func (s IntTupleSet) add(x, y IntTuple) IntTuple {
 	return z
 }
 
+// AddTo sets dst to x + y and returns dst, without allocating.
+// dst may be the same IntTuple as x or y.
+func (s IntTupleSet) AddTo(dst, x, y IntTuple) IntTuple {
+	s.checkDim(dst, x, y)
+	for i := range dst {
+		dst[i] = x[i] + y[i]
+	}
+	return dst
+}
+
+// SubTo sets dst to x - y and returns dst, without allocating.
+// dst may be the same IntTuple as x or y.
+func (s IntTupleSet) SubTo(dst, x, y IntTuple) IntTuple {
+	s.checkDim(dst, x, y)
+	for i := range dst {
+		dst[i] = x[i] - y[i]
+	}
+	return dst
+}
+
+// InverseTo sets dst to -x and returns dst, without allocating.
+// dst may be the same IntTuple as x.
+func (s IntTupleSet) InverseTo(dst, x IntTuple) IntTuple {
+	s.checkDim(dst, x)
+	for i := range dst {
+		dst[i] = -x[i]
+	}
+	return dst
+}
+
+func (s IntTupleSet) checkDim(xs ...IntTuple) {
+	for _, x := range xs {
+		if x.Size() != s.Size() {
+			log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: s.Size()})
+		}
+	}
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s IntTupleSet) Inverse(x Elem) Elem {
+	xElem := x.(IntTuple)
+	if xElem.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: xElem.Size(), Dim2: s.Size()})
+	}
+	return s.neg(xElem)
+}
+
+func (s IntTupleSet) neg(x IntTuple) IntTuple {
+	z := make(IntTuple, s.Size())
+	for i := range z {
+		z[i] = -x[i]
+	}
+	return z
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s IntTupleSet) Sub(x, y Elem) Elem {
+	xElem, yElem := x.(IntTuple), y.(IntTuple)
+	if xElem.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: xElem.Size(), Dim2: s.Size()})
+	}
+	if yElem.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: yElem.Size(), Dim2: s.Size()})
+	}
+	return s.sub(xElem, yElem)
+}
+
+func (s IntTupleSet) sub(x, y IntTuple) IntTuple {
+	z := make(IntTuple, s.Size())
+	for i := range z {
+		z[i] = x[i] - y[i]
+	}
+	return z
+}
+
 // Less returns x < y.
 func (s IntTupleSet) Less(x, y Elem) bool {
 	return x.(IntTuple).Compare(y) < 0
@@ -137,10 +213,107 @@ func (s IntTupleSet) Eq(x, y Elem) bool {
 	return x.(IntTuple).Compare(y) == 0
 }
 
+// Meet returns the componentwise minimum of x and y, the greatest lower
+// bound of x and y in the componentwise order, in which x ≤ y if
+// x[i] ≤ y[i] for each i. This is the order of the boxes of Interval,
+// e.g. Interval(Meet(x, y), Join(x, y)) is the smallest box containing
+// x and y, and it is contained in the lexicographic order of LessEqual.
+func (s IntTupleSet) Meet(x, y Elem) Elem {
+	return s.componentwise(x.(IntTuple), y.(IntTuple), -1)
+}
+
+// Join returns the componentwise maximum of x and y, the least upper
+// bound of x and y in the componentwise order, see Meet.
+func (s IntTupleSet) Join(x, y Elem) Elem {
+	return s.componentwise(x.(IntTuple), y.(IntTuple), 1)
+}
+
+// componentwise returns the componentwise minimum (sign < 0)
+// or maximum (sign > 0) of x and y.
+func (s IntTupleSet) componentwise(x, y IntTuple, sign int) IntTuple {
+	if x.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: s.Size()})
+	}
+	if y.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: y.Size(), Dim2: s.Size()})
+	}
+	z := x.Clone()
+	for i := range z {
+		if sign*(y[i]-z[i]) > 0 {
+			z[i] = y[i]
+		}
+	}
+	return z
+}
+
+// Succ returns the successor of x in the lexicographic order,
+// i.e. x with the last coordinate incremented.
+func (s IntTupleSet) Succ(x Elem) Elem {
+	return s.step(x.(IntTuple), 1)
+}
+
+// Pred returns the predecessor of x in the lexicographic order,
+// i.e. x with the last coordinate decremented.
+func (s IntTupleSet) Pred(x Elem) Elem {
+	return s.step(x.(IntTuple), -1)
+}
+
+func (s IntTupleSet) step(x IntTuple, d int) IntTuple {
+	if x.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: s.Size()})
+	}
+	if s.Size() == 0 {
+		log.Fatalf("cannot step from %v: %s has one element", x, s.Name())
+	}
+	y := x.Clone()
+	y[y.Size()-1] += d
+	return y
+}
+
+// Rank returns the torsion-free rank of the set, i.e. its tuple size.
+func (s IntTupleSet) Rank() int {
+	return s.Size()
+}
+
+// Sequence returns an iterator over all the IntTuples of the set,
+// in shells of increasing max-norm ‖x‖∞ = 0, 1, 2, …, each shell in
+// lexicographic order. The iterator never ends unless the tuple size is 0.
+func (s IntTupleSet) Sequence() Nexter {
+	return &IntTupleSeq{s: s, shell: s.Interval(s.Identity(), s.Identity()).Enumerate()}
+}
+
+// IntTupleSeq is an iterator over all the IntTuples of an IntTupleSet.
+type IntTupleSeq struct {
+	s     IntTupleSet
+	r     int // r is the max-norm of the current shell.
+	shell Nexter
+}
+
+// Next returns the next IntTuple in the sequence.
+func (n *IntTupleSeq) Next() (next Elem, more bool) {
+	for {
+		x, inShell := n.shell.Next()
+		r := n.r
+		if !inShell {
+			n.r++
+			lo, hi := make(IntTuple, n.s.Size()), make(IntTuple, n.s.Size())
+			for i := range lo {
+				lo[i], hi[i] = -n.r, n.r
+			}
+			n.shell = n.s.Interval(lo, hi).Enumerate()
+		}
+		if LInf.Of(x) == r { // skip the inner shells of the box.
+			return x, n.s.Size() > 0
+		}
+	}
+}
+
 // Interval returns a finite enumerable range.
 // { a | a1 ≤ a ≤ a2 }
+//
+// The range keeps copies of a1 and a2.
 func (s IntTupleSet) Interval(a1, a2 Elem) Enumerable {
-	return IntTupleInterval{Set: s, lo: a1.(IntTuple), hi: a2.(IntTuple)}
+	return IntTupleInterval{Set: s, lo: a1.(IntTuple).Clone(), hi: a2.(IntTuple).Clone()}
 }
 
 // IntTupleInterval is a finite subset of IntTuple
@@ -150,9 +323,51 @@ type IntTupleInterval struct {
 	lo, hi IntTuple
 }
 
-// IsIn returns true if x ∈ s.
+// IsIn returns true if x ∈ s, i.e. each coordinate of x is within the
+// bounds of the interval, the same box that Enumerate visits.
 func (r IntTupleInterval) IsIn(x Elem) bool {
-	return r.lo.Compare(x) <= 0 && r.hi.Compare(x) >= 0
+	xElem, ok := x.(IntTuple)
+	if !ok || xElem.Size() != r.lo.Size() {
+		return false
+	}
+	for i := range xElem {
+		if xElem[i] < r.lo[i] || xElem[i] > r.hi[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Lo returns a copy of the lower bound of the interval.
+func (r IntTupleInterval) Lo() IntTuple {
+	return r.lo.Clone()
+}
+
+// Hi returns a copy of the upper bound of the interval.
+func (r IntTupleInterval) Hi() IntTuple {
+	return r.hi.Clone()
+}
+
+func (r IntTupleInterval) superset() Set {
+	return r.Set
+}
+
+// Min returns a copy of the lower bound, the least IntTuple
+// of a non-empty interval.
+func (r IntTupleInterval) Min() Elem {
+	return r.lo.Clone()
+}
+
+// Max returns a copy of the upper bound, the greatest IntTuple
+// of a non-empty interval.
+func (r IntTupleInterval) Max() Elem {
+	return r.hi.Clone()
+}
+
+// Sequence returns an iterator over the IntTuples in the range,
+// the same as Enumerate.
+func (r IntTupleInterval) Sequence() Nexter {
+	return r.Enumerate()
 }
 
 // Name returns the description of the subset.
@@ -161,21 +376,69 @@ func (r IntTupleInterval) Name() string {
 }
 
 // Enumerate creates an iterator for looping over the IntTuple in the range.
+//
+// Every Elem returned by Next is a new IntTuple owned by the caller.
+// For an empty range, Next returns a nil Elem.
 func (r IntTupleInterval) Enumerate() Nexter {
-	return &IntTupleIter{IntTupleInterval: r, curr: r.lo}
+	if r.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	return &IntTupleIter{IntTupleInterval: r, curr: r.lo.Clone()}
+}
+
+// EnumerateShared creates an iterator for looping over the IntTuple in the
+// range, which does not allocate a new IntTuple for each step.
+//
+// Every Elem returned by Next aliases the same internal buffer, which is
+// overwritten by the following call to Next. Callers must copy the Elem
+// (or use the Slice method) to keep it. For an empty range,
+// Next returns a nil Elem.
+func (r IntTupleInterval) EnumerateShared() Nexter {
+	if r.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	curr := r.lo.Clone()
+	buf := make(IntTuple, r.lo.Size())
+	return &IntTupleIter{IntTupleInterval: r, curr: curr, buf: buf, bufElem: buf}
+}
+
+// Cardinality returns the number of IntTuple in the range.
+func (r IntTupleInterval) Cardinality() int {
+	card := 1
+	for i := range r.lo {
+		if r.hi[i] < r.lo[i] {
+			return 0
+		}
+		card *= r.hi[i] - r.lo[i] + 1
+	}
+	return card
 }
 
 // Slice returns ordered Elem in the range as a slice.
+//
+// The slice and the IntTuples are preallocated from the cardinality
+// of the range, with all the IntTuples sharing one backing array.
 func (r IntTupleInterval) Slice() []Elem {
-	var s []Elem
-	e := r.Enumerate()
+	card, size := r.Cardinality(), r.lo.Size()
+	s := make([]Elem, 0, card)
+	if card == 0 {
+		return s
+	}
+	backing := make([]int, card*size)
+	e := r.EnumerateShared()
 	for {
 		next, more := e.Next()
+		var x IntTuple
+		if len(backing) >= size {
+			x, backing = IntTuple(backing[:size:size]), backing[size:]
+		} else {
+			x = make(IntTuple, size)
+		}
+		copy(x, next.(IntTuple))
+		s = append(s, x)
 		if !more {
-			s = append(s, next)
 			break
 		}
-		s = append(s, next)
 	}
 	return s
 }
@@ -184,30 +447,43 @@ func (r IntTupleInterval) Slice() []Elem {
 type IntTupleIter struct {
 	IntTupleInterval
 	curr IntTuple
+
+	buf     IntTuple // buf is the shared buffer returned by Next, if non-nil.
+	bufElem Elem     // bufElem is buf as an Elem, to avoid allocating in Next.
+	done    bool
 }
 
-func (n *IntTupleIter) next(curr IntTuple) IntTuple {
-	next := make(IntTuple, curr.Size())
-	copy(next, curr)
-	carry := 1
-	for i := curr.Size() - 1; i >= 0; i-- {
-		if next[i]+carry > n.hi[i] {
-			next[i] = n.lo[i]
-		} else {
-			next[i] += carry
-			carry = 0
+// advance moves x to the next IntTuple in the range in place,
+// and returns false if there is no next IntTuple, setting x to hi.
+func (n *IntTupleIter) advance(x IntTuple) bool {
+	for i := x.Size() - 1; i >= 0; i-- {
+		if x[i] < n.hi[i] {
+			x[i]++
+			return true
 		}
+		x[i] = n.lo[i]
 	}
 	// If overflow, use max intTuple in range.
-	if carry == 1 {
-		copy(next, n.hi)
-	}
+	copy(x, n.hi)
+	return false
+}
+
+func (n *IntTupleIter) next(curr IntTuple) IntTuple {
+	next := curr.Clone()
+	n.advance(next)
 	return next
 }
 
 // Next returns the next Elem in the range, and indicates
 // if there are more elements in the range with more.
 func (n *IntTupleIter) Next() (next Elem, more bool) {
+	if n.buf != nil {
+		copy(n.buf, n.curr)
+		if !n.done {
+			n.done = !n.advance(n.curr)
+		}
+		return n.bufElem, !n.done
+	}
 	next = n.curr
 	n.curr = n.next(n.curr)
 	more = next.Compare(n.curr) != 0
@@ -215,8 +491,23 @@ func (n *IntTupleIter) Next() (next Elem, more bool) {
 }
 
 // IntTuple is an Elem in a IntTupleSet.
+//
+// IntTuple values are treated as immutable: the operations of IntTupleSet
+// return new tuples and never modify their arguments, and containers
+// such as FiniteSet and ordmap.Map store copies of the tuples they are
+// given. The tuples they return, e.g. by FiniteSet.Slice, are the stored
+// ones and must not be modified. Only AddTo, SubTo, InverseTo,
+// Accumulate and the buffer of EnumerateShared modify tuples in place.
+// Use Freeze for a representation which cannot be modified at all.
 type IntTuple []int
 
+// Clone returns a copy of e.
+func (e IntTuple) Clone() IntTuple {
+	c := make(IntTuple, len(e))
+	copy(c, e)
+	return c
+}
+
 // Size returns the tuple size of e.
 func (e IntTuple) Size() int {
 	return len(e)
@@ -242,6 +533,16 @@ func (e IntTuple) String() string {
 	return buf.String()
 }
 
+// Accumulate adds x to e in place, i.e. e += x, without allocating.
+func (e IntTuple) Accumulate(x IntTuple) {
+	if x.Size() != e.Size() {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: e.Size()})
+	}
+	for i := range e {
+		e[i] += x[i]
+	}
+}
+
 // Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x.
 func (e IntTuple) Compare(x Elem) int {
 	tuple := x.(IntTuple)
@@ -250,3 +250,250 @@ func TestIntervalSubset(t *testing.T) {
 		t.Errorf("%s should not be in the interval %s", v.String(), subset.Name())
 	}
 }
+
+func TestIntTupleAddTo(t *testing.T) {
+	s := NewIntTuple(2)
+	x, y := s.Tuple(1, 2), s.Tuple(3, 5)
+	dst := make(IntTuple, 2)
+	if want, got := s.Tuple(4, 7), s.AddTo(dst, x, y); want.Compare(got) != 0 || want.Compare(dst) != 0 {
+		t.Errorf("AddTo(%v, %v) expected to be %v but got %v", x, y, want, got)
+	}
+	if want, got := s.Tuple(-2, -3), s.SubTo(dst, x, y); want.Compare(got) != 0 {
+		t.Errorf("SubTo(%v, %v) expected to be %v but got %v", x, y, want, got)
+	}
+	if want, got := s.Tuple(-1, -2), s.InverseTo(dst, x); want.Compare(got) != 0 {
+		t.Errorf("InverseTo(%v) expected to be %v but got %v", x, want, got)
+	}
+	// dst aliasing x.
+	s.AddTo(x, x, y)
+	if want := s.Tuple(4, 7); want.Compare(x) != 0 {
+		t.Errorf("AddTo(x, x, y) expected x to be %v but got %v", want, x)
+	}
+	x.Accumulate(y)
+	if want := s.Tuple(7, 12); want.Compare(x) != 0 {
+		t.Errorf("Accumulate expected %v but got %v", want, x)
+	}
+}
+
+func TestEnumerateShared(t *testing.T) {
+	s := NewIntTuple(3)
+	iv := s.Interval(s.Tuple(0, -1, 2), s.Tuple(2, 1, 3)).(IntTupleInterval)
+	want := iv.Slice()
+	if w, g := iv.Cardinality(), len(want); w != g {
+		t.Errorf("expected cardinality %d but got %d Elems", w, g)
+	}
+	e := iv.EnumerateShared()
+	var prev Elem
+	for i := 0; ; i++ {
+		n, more := e.Next()
+		if want[i].Compare(n) != 0 {
+			t.Errorf("expected %s but got %s", want[i], n)
+		}
+		if prev != nil && &prev.(IntTuple)[0] != &n.(IntTuple)[0] {
+			t.Errorf("expected Next to reuse its buffer")
+		}
+		prev = n
+		if !more {
+			if i != len(want)-1 {
+				t.Errorf("expected %d results but got %d", len(want), i+1)
+			}
+			break
+		}
+	}
+	if want := s.Tuple(0, -1, 2); want.Compare(iv.lo) != 0 {
+		t.Errorf("EnumerateShared should not modify the interval lower bound %s", iv.lo)
+	}
+}
+
+func TestEmptyInterval(t *testing.T) {
+	s := NewIntTuple(2)
+	for _, iv := range []IntTupleInterval{
+		s.Interval(s.Tuple(2, 0), s.Tuple(1, 0)).(IntTupleInterval),
+		s.Interval(s.Tuple(0, 3), s.Tuple(5, 2)).(IntTupleInterval),
+	} {
+		if want, got := 0, len(iv.Slice()); want != got {
+			t.Errorf("expected %d Elems in %s but got %v", want, iv.Name(), iv.Slice())
+		}
+		for _, e := range []Nexter{iv.Enumerate(), iv.EnumerateShared()} {
+			if next, more := e.Next(); next != nil || more {
+				t.Errorf("expected empty iterator of %s but got %v, %t", iv.Name(), next, more)
+			}
+		}
+	}
+}
+
+func TestIntervalIsIn(t *testing.T) {
+	s := NewIntTuple(2)
+	iv := s.Interval(s.Tuple(0, 0), s.Tuple(2, 2)).(IntTupleInterval)
+	in := make(map[string]bool)
+	for _, x := range iv.Slice() {
+		in[x.String()] = true
+		if !iv.IsIn(x) {
+			t.Errorf("expected enumerated %v to be in %s", x, iv.Name())
+		}
+	}
+	// (1,5) is between (0,0) and (2,2) lexicographically, but outside the box.
+	for _, x := range []IntTuple{s.Tuple(1, 5), s.Tuple(-1, 1), s.Tuple(3, 0), {1, 1, 1}} {
+		if iv.IsIn(x) || in[x.String()] {
+			t.Errorf("expected %v not to be in %s", x, iv.Name())
+		}
+	}
+}
+
+func TestEnumerateSharedAllocs(t *testing.T) {
+	s := NewIntTuple(2)
+	iv := s.Interval(s.Tuple(0, 0), s.Tuple(9, 9)).(IntTupleInterval)
+	e := iv.EnumerateShared()
+	allocs := testing.AllocsPerRun(50, func() { e.Next() })
+	if allocs != 0 {
+		t.Errorf("expected no allocation per Next but got %v", allocs)
+	}
+	x, y, dst := s.Tuple(1, 2), s.Tuple(3, 4), s.Tuple(0, 0)
+	if allocs := testing.AllocsPerRun(50, func() { s.AddTo(dst, x, y) }); allocs != 0 {
+		t.Errorf("expected no allocation per AddTo but got %v", allocs)
+	}
+}
+
+func BenchmarkIntTupleAdd(b *testing.B) {
+	s := NewIntTuple(3)
+	var sum Elem = s.Identity()
+	x := s.Tuple(1, 2, 3)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum = s.Add(sum, x)
+	}
+}
+
+func BenchmarkIntTupleAddTo(b *testing.B) {
+	s := NewIntTuple(3)
+	sum := s.Identity().(IntTuple)
+	x := s.Tuple(1, 2, 3)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.AddTo(sum, sum, x)
+	}
+}
+
+func benchmarkEnumerate(b *testing.B, enumerate func(IntTupleInterval) Nexter) {
+	s := NewIntTuple(3)
+	iv := s.Interval(s.Tuple(0, 0, 0), s.Tuple(19, 19, 19)).(IntTupleInterval)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		e := enumerate(iv)
+		for {
+			if _, more := e.Next(); !more {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkEnumerate(b *testing.B) {
+	benchmarkEnumerate(b, IntTupleInterval.Enumerate)
+}
+
+func BenchmarkEnumerateShared(b *testing.B) {
+	benchmarkEnumerate(b, IntTupleInterval.EnumerateShared)
+}
+
+func BenchmarkSlice(b *testing.B) {
+	s := NewIntTuple(3)
+	iv := s.Interval(s.Tuple(0, 0, 0), s.Tuple(19, 19, 19))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		iv.Slice()
+	}
+}
+
+// Tests tuples are copied on construction and on return.
+func TestIntTupleImmutable(t *testing.T) {
+	s := NewIntTuple(2)
+	v := []int{1, 2}
+	x := s.Tuple(v...)
+	v[0] = 9
+	if want, got := "(1,2)", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	y := x.Clone()
+	y[1] = 9
+	if want, got := "(1,2)", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+
+	lo, hi := s.Tuple(0, 0), s.Tuple(1, 1)
+	r := s.Interval(lo, hi).(IntTupleInterval)
+	lo[0], hi[0] = 5, 5
+	r.Lo()[1] = 5
+	e := r.Enumerate()
+	for {
+		next, more := e.Next()
+		next.(IntTuple)[0] = 7 // must not affect the iterator or the interval.
+		if !more {
+			break
+		}
+	}
+	if want, got := "(0,0)≤..≤(1,1)", r.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := 4, len(r.Slice()); want != got {
+		t.Errorf("expected %d elements but got %d", want, got)
+	}
+}
+
+func TestIntTupleOrder(t *testing.T) {
+	s := NewIntTuple(2)
+	x, y := s.Tuple(1, 5), s.Tuple(2, -1)
+	if want, got := s.Tuple(1, -1), s.Meet(x, y); want.Compare(got) != 0 {
+		t.Errorf("expected %v ∧ %v = %v but got %v", x, y, want, got)
+	}
+	if want, got := s.Tuple(2, 5), s.Join(x, y); want.Compare(got) != 0 {
+		t.Errorf("expected %v ∨ %v = %v but got %v", x, y, want, got)
+	}
+	box := s.Interval(s.Meet(x, y), s.Join(x, y)).(IntTupleInterval)
+	if !box.IsIn(x) || !box.IsIn(y) {
+		t.Errorf("expected %v and %v to be in %s", x, y, box.Name())
+	}
+	if !s.LessEqual(s.Meet(x, y), x) || !s.LessEqual(y, s.Join(x, y)) {
+		t.Errorf("expected the componentwise order to be contained in ≤")
+	}
+	if want, got := s.Tuple(1, 6), s.Succ(x); want.Compare(got) != 0 {
+		t.Errorf("expected successor of %v to be %v but got %v", x, want, got)
+	}
+	if want, got := x, s.Pred(s.Succ(x)); want.Compare(got) != 0 {
+		t.Errorf("expected predecessor of successor of %v to be %v but got %v", x, want, got)
+	}
+	if want, got := 2, s.Rank(); want != got {
+		t.Errorf("expected rank %d but got %d", want, got)
+	}
+}
+
+func TestIntTupleSequence(t *testing.T) {
+	s := NewIntTuple(2)
+	seq := s.Sequence()
+	seen := map[string]bool{}
+	for i := 0; i < 25; i++ { // shells 0, 1 and 2 of ℤxℤ.
+		next, more := seq.Next()
+		if !more {
+			t.Fatalf("expected sequence of %s not to end", s.Name())
+		}
+		want := 2 // 1 element of norm 0, 8 of norm 1, 16 of norm 2.
+		if i < 9 {
+			want = (i + 8) / 9
+		}
+		if got := LInf.Of(next); want != got {
+			t.Errorf("expected element %d %v to have norm %d but got %d", i, next, want, got)
+		}
+		if seen[next.String()] {
+			t.Errorf("element %v repeated", next)
+		}
+		seen[next.String()] = true
+	}
+	if next, _ := seq.Next(); LInf.Of(next) != 3 {
+		t.Errorf("expected shell 3 after 25 elements but got %v", next)
+	}
+
+	x, more := NewIntTuple(0).Sequence().Next()
+	if want, got := "()", x.String(); want != got || more {
+		t.Errorf("expected the only element of ∅ to be %s but got %s (more=%t)", want, got, more)
+	}
+}
@@ -0,0 +1,365 @@
+package set
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LocalSet is the set ℤ[1/n] of rationals a/n^k for integers a and k ≥ 0,
+// which forms a torsion-free abelian group of rank 1 under Add.
+//
+// For example, ℤ[1/2] is the set of dyadic rationals such as 3/8, and
+// ℤ[1/6] = ℤ[1/2,1/3] is the set of rationals whose denominators have
+// no prime factors other than 2 and 3. Arithmetic is exact.
+type LocalSet struct {
+	n      int
+	primes []int // prime factors of n, in ascending order.
+}
+
+// NewLocal returns the set ℤ[1/n], where n > 0. ℤ[1/1] is ℤ.
+func NewLocal(n int) LocalSet {
+	if n <= 0 {
+		log.Fatalf("cannot create ℤ[1/%d]: n must be positive", n)
+	}
+	exps := make(map[int]int)
+	factor(n, exps, 1)
+	s := LocalSet{n: n}
+	for p := range exps {
+		s.primes = append(s.primes, p)
+	}
+	sort.Ints(s.primes)
+	return s
+}
+
+// NewDyadic returns the set of dyadic rationals ℤ[1/2].
+func NewDyadic() LocalSet {
+	return NewLocal(2)
+}
+
+// Primes returns the prime factors of n, the primes which
+// can divide the denominators of the rationals in ℤ[1/n].
+func (s LocalSet) Primes() []int {
+	return append([]int(nil), s.primes...)
+}
+
+// Frac returns the rational num/den, which must be a member of the set,
+// otherwise it throws a runtime error.
+func (s LocalSet) Frac(num, den int) LocalRat {
+	if den == 0 {
+		log.Fatalf("cannot create %d/%d in %s: zero denominator", num, den, s.Name())
+	}
+	x := LocalRat{r: big.NewRat(int64(num), int64(den))}
+	if !s.IsIn(x) {
+		log.Fatalf("cannot create %d/%d in %s: not a member", num, den, s.Name())
+	}
+	return x
+}
+
+// FromRat returns r as an Elem of the set, and false if r ∉ s.
+func (s LocalSet) FromRat(r *big.Rat) (LocalRat, bool) {
+	x := LocalRat{r: new(big.Rat).Set(r)}
+	return x, s.IsIn(x)
+}
+
+// Identity returns 0, the identity of the set.
+func (s LocalSet) Identity() Elem {
+	return LocalRat{}
+}
+
+// IsIn returns true if x ∈ s, i.e. the denominator
+// of x has no prime factors other than those of n.
+func (s LocalSet) IsIn(x Elem) bool {
+	xElem, ok := x.(LocalRat)
+	if !ok {
+		return false
+	}
+	den := new(big.Int).Set(xElem.rat().Denom())
+	q, m := new(big.Int), new(big.Int)
+	for _, p := range s.primes {
+		bp := big.NewInt(int64(p))
+		for {
+			q.QuoRem(den, bp, m)
+			if m.Sign() != 0 {
+				break
+			}
+			den.Set(q)
+		}
+	}
+	return den.IsInt64() && den.Int64() == 1
+}
+
+// Name returns the formal name of the set, e.g. ℤ[1/2], or ℤ if n = 1.
+func (s LocalSet) Name() string {
+	if s.n == 1 {
+		return "ℤ"
+	}
+	return fmt.Sprintf("ℤ[1/%d]", s.n)
+}
+
+// Rank returns 1, the torsion-free rank of the set.
+func (s LocalSet) Rank() int {
+	return 1
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s LocalSet) Add(x, y Elem) Elem {
+	return LocalRat{r: new(big.Rat).Add(x.(LocalRat).rat(), y.(LocalRat).rat())}
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s LocalSet) Sub(x, y Elem) Elem {
+	return LocalRat{r: new(big.Rat).Sub(x.(LocalRat).rat(), y.(LocalRat).rat())}
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s LocalSet) Inverse(x Elem) Elem {
+	return LocalRat{r: new(big.Rat).Neg(x.(LocalRat).rat())}
+}
+
+// Less returns x < y.
+func (s LocalSet) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s LocalSet) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s LocalSet) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// Height returns the p-height of x in the set, i.e. the largest k such
+// that x = p^k·y for some y in the set, which is infinite if p divides n.
+// The height of 0 is infinite.
+//
+// p must be a prime, otherwise it throws a runtime error.
+func (s LocalSet) Height(x Elem, p int) Height {
+	if !isPrimeInt(p) {
+		log.Fatalf("cannot compute %d-height in %s: %d is not a prime", p, s.Name(), p)
+	}
+	xElem := x.(LocalRat)
+	if xElem.rat().Sign() == 0 {
+		return InfiniteHeight
+	}
+	for _, q := range s.primes {
+		if q == p {
+			return InfiniteHeight
+		}
+	}
+	num := new(big.Int).Abs(xElem.rat().Num())
+	q, m, bp := new(big.Int), new(big.Int), big.NewInt(int64(p))
+	h := Height(0)
+	for {
+		q.QuoRem(num, bp, m)
+		if m.Sign() != 0 {
+			return h
+		}
+		h++
+		num.Set(q)
+	}
+}
+
+// Characteristic returns the characteristic of x, the sequence of
+// p-heights of x for the primes p = 2, 3, 5, ...
+//
+// It throws a runtime error if x is 0, whose heights are all infinite.
+// The numerator of x is factored by trial division, so it returns an
+// error if the numerator does not fit in an int.
+func (s LocalSet) Characteristic(x Elem) (Characteristic, error) {
+	xElem := x.(LocalRat)
+	if xElem.rat().Sign() == 0 {
+		log.Fatalf("cannot compute characteristic of 0 in %s", s.Name())
+	}
+	c := Characteristic{inf: s.Primes(), fin: make(map[int]int)}
+	num := new(big.Int).Abs(xElem.rat().Num())
+	if !num.IsInt64() || int64(int(num.Int64())) != num.Int64() {
+		return Characteristic{}, fmt.Errorf("cannot compute characteristic of %s: numerator too large to factor", x)
+	}
+	exps := make(map[int]int)
+	factor(int(num.Int64()), exps, 1)
+	for p, e := range exps {
+		if c.Height(p) != InfiniteHeight {
+			c.fin[p] = e
+		}
+	}
+	return c, nil
+}
+
+// Type returns the type of the set, i.e. the characteristic of 1, which
+// represents the characteristics of all the nonzero Elems of the set.
+//
+// Two rank 1 torsion-free groups are isomorphic if and only if they
+// have the same type, e.g. ℤ[1/6] and ℤ[1/12] have the same type.
+func (s LocalSet) Type() Characteristic {
+	return Characteristic{inf: s.Primes()}
+}
+
+// ParseElem parses a rational in decimal, e.g. -3/8 or 5.
+// It accepts the output of LocalRat.String.
+func (s LocalSet) ParseElem(text string) (Elem, error) {
+	t := strings.TrimSpace(text)
+	numText, denText := t, "1"
+	if i := strings.IndexByte(t, '/'); i >= 0 {
+		numText, denText = strings.TrimSpace(t[:i]), strings.TrimSpace(t[i+1:])
+	}
+	num, ok := parseDecimal(numText, true)
+	if !ok {
+		return nil, ParseError{Text: text, Pos: strings.Index(text, t), Msg: fmt.Sprintf("invalid numerator %q, expecting decimal integer", numText)}
+	}
+	den, ok := parseDecimal(denText, false)
+	if !ok || den.Sign() == 0 {
+		return nil, ParseError{Text: text, Pos: strings.LastIndex(text, denText), Msg: fmt.Sprintf("invalid denominator %q, expecting positive decimal integer", denText)}
+	}
+	x := LocalRat{r: new(big.Rat).SetFrac(num, den)}
+	if !s.IsIn(x) {
+		return nil, ParseError{Text: text, Pos: strings.LastIndex(text, denText), Msg: fmt.Sprintf("denominator of %s has prime factors other than those of %d", x, s.n)}
+	}
+	return x, nil
+}
+
+// parseDecimal parses the decimal digits of text, with a leading - if signed.
+func parseDecimal(text string, signed bool) (*big.Int, bool) {
+	digits := text
+	if signed {
+		digits = strings.TrimPrefix(text, "-")
+	}
+	if digits == "" {
+		return nil, false
+	}
+	for i := 0; i < len(digits); i++ {
+		if !isDigit(digits[i]) {
+			return nil, false
+		}
+	}
+	return new(big.Int).SetString(text, 10)
+}
+
+// LocalRat is an Elem of a LocalSet, an exact rational.
+//
+// The zero value is 0.
+type LocalRat struct {
+	r *big.Rat // not modified after creation, nil if 0.
+}
+
+func (x LocalRat) rat() *big.Rat {
+	if x.r == nil {
+		return new(big.Rat)
+	}
+	return x.r
+}
+
+// Rat returns x as a big.Rat.
+func (x LocalRat) Rat() *big.Rat {
+	return new(big.Rat).Set(x.rat())
+}
+
+// Float64 returns the nearest float64 value of x.
+func (x LocalRat) Float64() float64 {
+	f, _ := x.rat().Float64()
+	return f
+}
+
+// String returns x in lowest terms, e.g. -3/8, or 5 if x is an integer.
+func (x LocalRat) String() string {
+	return x.rat().RatString()
+}
+
+// Compare returns 0 if x == y, -ve int if x < y, +ve int if x > y,
+// comparing the rationals by value.
+func (x LocalRat) Compare(y Elem) int {
+	return x.rat().Cmp(y.(LocalRat).rat())
+}
+
+// Key returns x in lowest terms as a string.
+func (x LocalRat) Key() string {
+	return x.String()
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of x.
+func (x LocalRat) Hash() uint64 {
+	return hashKey(x.Key())
+}
+
+// Height is the p-height of an Elem, which can be infinite.
+type Height int
+
+// InfiniteHeight is the Height of an Elem which
+// is divisible by arbitrary powers of p.
+const InfiniteHeight Height = -1
+
+// String returns the height, or ∞ if it is infinite.
+func (h Height) String() string {
+	if h == InfiniteHeight {
+		return "∞"
+	}
+	return strconv.Itoa(int(h))
+}
+
+// Characteristic is the sequence of p-heights of an Elem of a rank 1
+// torsion-free group for the primes p = 2, 3, 5, ..., in which only
+// finitely many heights are nonzero.
+type Characteristic struct {
+	inf []int       // primes of infinite height, in ascending order.
+	fin map[int]int // nonzero finite heights.
+}
+
+// Height returns the p-height of the characteristic.
+func (c Characteristic) Height(p int) Height {
+	for _, q := range c.inf {
+		if q == p {
+			return InfiniteHeight
+		}
+	}
+	return Height(c.fin[p])
+}
+
+// Equivalent returns true if c and d differ at finitely many primes,
+// and only at primes where both have finite heights, i.e. c and d are
+// characteristics of the same type.
+func (c Characteristic) Equivalent(d Characteristic) bool {
+	if len(c.inf) != len(d.inf) {
+		return false
+	}
+	for i := range c.inf {
+		if c.inf[i] != d.inf[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the nonzero heights labelled by their primes in
+// ascending order, e.g. (2:∞,5:1,7:2,…) for the heights ∞, 1 and 2
+// at 2, 5 and 7, and 0 at the other primes.
+func (c Characteristic) String() string {
+	ps := append([]int(nil), c.inf...)
+	for p := range c.fin {
+		ps = append(ps, p)
+	}
+	sort.Ints(ps)
+	heights := make([]string, 0, len(ps)+1)
+	for _, p := range ps {
+		heights = append(heights, strconv.Itoa(p)+":"+c.Height(p).String())
+	}
+	return "(" + strings.Join(append(heights, "…"), ",") + ")"
+}
+
+// isPrimeInt returns true if p is a prime.
+func isPrimeInt(p int) bool {
+	if p < 2 {
+		return false
+	}
+	for d := 2; d*d <= p; d++ {
+		if p%d == 0 {
+			return false
+		}
+	}
+	return true
+}
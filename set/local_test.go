@@ -0,0 +1,119 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDyadic(t *testing.T) {
+	s := NewDyadic()
+	if want, got := "ℤ[1/2]", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	x, y := s.Frac(3, 8), s.Frac(-5, 4)
+	if want, got := "-7/8", s.Add(x, y).String(); want != got {
+		t.Errorf("expected 3/8 + -5/4 = %s but got %s", want, got)
+	}
+	if want, got := "13/8", s.Sub(x, y).String(); want != got {
+		t.Errorf("expected 3/8 - -5/4 = %s but got %s", want, got)
+	}
+	if want, got := s.Identity(), s.Add(x, s.Inverse(x)); want.Compare(got) != 0 || Key(want) != Key(got) {
+		t.Errorf("expected x + -x = %v but got %v", want, got)
+	}
+	if !s.Less(y, x) || !s.LessEqual(x, x) || s.Less(x, y) {
+		t.Errorf("expected %v < %v", y, x)
+	}
+	if s.IsIn(NewLocal(3).Frac(1, 3)) {
+		t.Errorf("expected 1/3 not to be in %s", s.Name())
+	}
+	if want, got := 0.375, x.Float64(); want != got {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+}
+
+func TestLocalParseElem(t *testing.T) {
+	s := NewLocal(6)
+	x, err := s.ParseElem(" -10/12 ")
+	if err != nil {
+		t.Fatalf("cannot parse: %v", err)
+	}
+	if want, got := "-5/6", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	for _, text := range []string{"1/5", "0.5", "x", "1/0", "0x10", "0b11/0b10", "0x1p-2", "1e3", "+3", "3/-2", "1_0", "/2", "1/"} {
+		if x, err := s.ParseElem(text); err == nil {
+			t.Errorf("expected parse error for %q but got %v", text, x)
+		}
+	}
+	// Leading zeros are decimal on both sides.
+	for text, want := range map[string]string{"010": "10", "1/012": "1/12", "-0/3": "0"} {
+		x, err := s.ParseElem(text)
+		if err != nil {
+			t.Errorf("cannot parse %q: %v", text, err)
+		} else if got := x.String(); want != got {
+			t.Errorf("expected %q to parse as %s but got %s", text, want, got)
+		}
+	}
+}
+
+func TestLocalType(t *testing.T) {
+	s := NewLocal(12)
+	if want, got := "[2 3]", fmt.Sprint(s.Primes()); want != got {
+		t.Errorf("expected primes %s but got %s", want, got)
+	}
+	x := s.Frac(45, 4) // 3^2·5/2^2
+	if want, got := InfiniteHeight, s.Height(x, 3); want != got {
+		t.Errorf("expected 3-height %v but got %v", want, got)
+	}
+	if want, got := Height(1), s.Height(x, 5); want != got {
+		t.Errorf("expected 5-height %v but got %v", want, got)
+	}
+	c, err := s.Characteristic(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "(2:∞,3:∞,5:1,…)", c.String(); want != got {
+		t.Errorf("expected characteristic %s but got %s", want, got)
+	}
+	if want, got := "(2:∞,3:∞,…)", s.Type().String(); want != got {
+		t.Errorf("expected type %s but got %s", want, got)
+	}
+	if !c.Equivalent(NewLocal(6).Type()) {
+		t.Errorf("expected %s and %s to have the same type", s.Name(), NewLocal(6).Name())
+	}
+	if want, got := "[2 3 1000003]", fmt.Sprint(NewLocal(1<<40*3*1000003).Primes()); want != got {
+		t.Errorf("expected primes %s but got %s", want, got)
+	}
+	if NewDyadic().Type().Equivalent(s.Type()) {
+		t.Errorf("expected %s and %s to have different types", NewDyadic().Name(), s.Name())
+	}
+	if want, got := "(…)", NewLocal(1).Type().String(); want != got {
+		t.Errorf("expected type of ℤ to be %s but got %s", want, got)
+	}
+}
+
+func TestLocalCharacteristicLarge(t *testing.T) {
+	s := NewDyadic()
+	c, err := s.Characteristic(s.Frac(3*1000000007, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "(2:∞,3:1,1000000007:1,…)", c.String(); want != got {
+		t.Errorf("expected characteristic %s but got %s", want, got)
+	}
+	x, err := s.ParseElem("340282366920938463463374607431768211457") // 2^128 + 1
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Characteristic(x); err == nil {
+		t.Errorf("expected error for numerator %s too large to factor", x)
+	}
+}
+
+func TestIsPrimeInt(t *testing.T) {
+	for p, want := range map[int]bool{-3: false, 0: false, 1: false, 2: true, 9: false, 97: true, 1000003: true} {
+		if got := isPrimeInt(p); want != got {
+			t.Errorf("expected isPrimeInt(%d) = %t but got %t", p, want, got)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// errShortBuffer is the error where binary data ends before
+// the encoded value is complete.
+var errShortBuffer = errors.New("binary data is truncated")
+
+// maxInt and minInt are the bounds of int, which may be 32 bits.
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// MarshalText implements encoding.TextMarshaler using the String representation.
+func (e IntTuple) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// If e is non-nil, the decoded tuple must have the same size as e.
+func (e *IntTuple) UnmarshalText(text []byte) error {
+	x, err := ParseIntTuple(string(text))
+	if err != nil {
+		return err
+	}
+	return e.set(x)
+}
+
+// MarshalJSON implements json.Marshaler. The tuple is encoded as
+// a JSON array of integers, e.g. [1,2].
+func (e IntTuple) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]int(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// If e is non-nil, the decoded tuple must have the same size as e.
+func (e *IntTuple) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var v []int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("cannot unmarshal %s into IntTuple: %v", data, err)
+	}
+	if v == nil {
+		v = []int{}
+	}
+	return e.set(IntTuple(v))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The tuple is
+// encoded as the uvarint size followed by the zigzag varint of each
+// coordinate.
+func (e IntTuple) MarshalBinary() ([]byte, error) {
+	return e.appendBinary(make([]byte, 0, binary.MaxVarintLen64*(e.Size()+1))), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// If e is non-nil, the decoded tuple must have the same size as e.
+func (e *IntTuple) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	x, err := readIntTuple(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("cannot unmarshal IntTuple: %d trailing bytes", r.Len())
+	}
+	return e.set(x)
+}
+
+// set sets e to x, checking the dimension if e is non-nil.
+func (e *IntTuple) set(x IntTuple) error {
+	if *e != nil && e.Size() != x.Size() {
+		return fmt.Errorf("cannot unmarshal %s: %v", x, MismatchDimErr{Dim1: x.Size(), Dim2: e.Size()})
+	}
+	*e = x
+	return nil
+}
+
+func (e IntTuple) appendBinary(buf []byte) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(e.Size()))]...)
+	for _, v := range e {
+		buf = append(buf, tmp[:binary.PutVarint(tmp[:], int64(v))]...)
+	}
+	return buf
+}
+
+func readIntTuple(r *bytes.Reader) (IntTuple, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal IntTuple size: %v", binaryErr(err))
+	}
+	if size > uint64(r.Len()) { // each coordinate takes at least one byte.
+		return nil, fmt.Errorf("cannot unmarshal IntTuple of size %d: %v", size, errShortBuffer)
+	}
+	x := make(IntTuple, size)
+	for i := range x {
+		v, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unmarshal IntTuple coordinate %d: %v", i, binaryErr(err))
+		}
+		if v < int64(minInt) || v > int64(maxInt) {
+			return nil, fmt.Errorf("cannot unmarshal IntTuple coordinate %d: %d overflows int", i, v)
+		}
+		x[i] = int(v)
+	}
+	return x, nil
+}
+
+// binaryErr converts the io errors of binary decoding into errShortBuffer.
+func binaryErr(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errShortBuffer
+	}
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler using the Name of the set.
+func (s IntTupleSet) MarshalText() ([]byte, error) {
+	return []byte(s.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts all the forms accepted by ParseSet.
+func (s *IntTupleSet) UnmarshalText(text []byte) error {
+	p, err := ParseSet(string(text))
+	if err != nil {
+		return err
+	}
+	ts, ok := p.(IntTupleSet)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal %q: %s is not an IntTupleSet", text, p.Name())
+	}
+	*s = ts
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The set is encoded
+// as a JSON string of its Name, e.g. "ℤxℤ".
+func (s IntTupleSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Name())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *IntTupleSet) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("cannot unmarshal %s into IntTupleSet: %v", data, err)
+	}
+	return s.UnmarshalText([]byte(name))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The set is encoded as the uvarint tuple size.
+func (s IntTupleSet) MarshalBinary() ([]byte, error) {
+	if s.Size() < 0 {
+		return nil, fmt.Errorf("cannot marshal IntTupleSet of size %d", s.Size())
+	}
+	var buf [binary.MaxVarintLen64]byte
+	return buf[:binary.PutUvarint(buf[:], uint64(s.Size()))], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The size must fit in an int.
+func (s *IntTupleSet) UnmarshalBinary(data []byte) error {
+	size, n := binary.Uvarint(data)
+	if n == 0 {
+		return fmt.Errorf("cannot unmarshal IntTupleSet size: %v", errShortBuffer)
+	}
+	if n < 0 || size > uint64(maxInt) {
+		return fmt.Errorf("cannot unmarshal IntTupleSet: size overflows int")
+	}
+	if n != len(data) {
+		return fmt.Errorf("cannot unmarshal IntTupleSet: %d trailing bytes", len(data)-n)
+	}
+	*s = IntTupleSet(size)
+	return nil
+}
+
+// intervalSep separates the bounds in the text form of an interval.
+const intervalSep = "≤..≤"
+
+// MarshalText implements encoding.TextMarshaler using the Name
+// of the interval, e.g. "(0,0)≤..≤(2,2)".
+func (r IntTupleInterval) MarshalText() ([]byte, error) {
+	return []byte(r.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The bounds must have the same size.
+func (r *IntTupleInterval) UnmarshalText(text []byte) error {
+	bounds := strings.Split(string(text), intervalSep)
+	if len(bounds) != 2 {
+		return fmt.Errorf("cannot unmarshal %q into IntTupleInterval: expecting lo%shi", text, intervalSep)
+	}
+	lo, err := ParseIntTuple(bounds[0])
+	if err != nil {
+		return err
+	}
+	hi, err := ParseIntTuple(bounds[1])
+	if err != nil {
+		return err
+	}
+	return r.set(lo, hi)
+}
+
+// intervalJSON is the JSON representation of an IntTupleInterval.
+type intervalJSON struct {
+	Lo IntTuple `json:"lo"`
+	Hi IntTuple `json:"hi"`
+}
+
+// MarshalJSON implements json.Marshaler. The interval is encoded
+// as a JSON object of its bounds, e.g. {"lo":[0,0],"hi":[2,2]}.
+func (r IntTupleInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(intervalJSON{Lo: r.lo, Hi: r.hi})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The bounds must have the same size.
+func (r *IntTupleInterval) UnmarshalJSON(data []byte) error {
+	var v intervalJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("cannot unmarshal %s into IntTupleInterval: %v", data, err)
+	}
+	if v.Lo == nil || v.Hi == nil {
+		return fmt.Errorf("cannot unmarshal %s into IntTupleInterval: missing bounds", data)
+	}
+	return r.set(v.Lo, v.Hi)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The interval is
+// encoded as the binary form of lo followed by the binary form of hi.
+func (r IntTupleInterval) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, binary.MaxVarintLen64*(r.lo.Size()+r.hi.Size()+2))
+	return r.hi.appendBinary(r.lo.appendBinary(buf)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The bounds must have the same size.
+func (r *IntTupleInterval) UnmarshalBinary(data []byte) error {
+	rd := bytes.NewReader(data)
+	lo, err := readIntTuple(rd)
+	if err != nil {
+		return err
+	}
+	hi, err := readIntTuple(rd)
+	if err != nil {
+		return err
+	}
+	if rd.Len() > 0 {
+		return fmt.Errorf("cannot unmarshal IntTupleInterval: %d trailing bytes", rd.Len())
+	}
+	return r.set(lo, hi)
+}
+
+// set sets the bounds of r to lo and hi, and the set to the IntTupleSet
+// of their size, unless r already belongs to a set of a different size.
+func (r *IntTupleInterval) set(lo, hi IntTuple) error {
+	if lo.Size() != hi.Size() {
+		return fmt.Errorf("cannot unmarshal interval %s%s%s: %v", lo, intervalSep, hi, MismatchDimErr{Dim1: lo.Size(), Dim2: hi.Size()})
+	}
+	if s, ok := r.Set.(IntTupleSet); ok && s.Size() != lo.Size() {
+		return fmt.Errorf("cannot unmarshal interval %s%s%s: %v", lo, intervalSep, hi, MismatchDimErr{Dim1: lo.Size(), Dim2: s.Size()})
+	}
+	r.Set, r.lo, r.hi = NewIntTuple(lo.Size()), lo, hi
+	return nil
+}
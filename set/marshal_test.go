@@ -0,0 +1,159 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler     = IntTuple{}
+	_ encoding.TextUnmarshaler   = (*IntTuple)(nil)
+	_ encoding.BinaryMarshaler   = IntTuple{}
+	_ encoding.BinaryUnmarshaler = (*IntTuple)(nil)
+	_ json.Marshaler             = IntTuple{}
+	_ json.Unmarshaler           = (*IntTuple)(nil)
+
+	_ encoding.TextMarshaler     = IntTupleSet(0)
+	_ encoding.BinaryUnmarshaler = (*IntTupleSet)(nil)
+	_ json.Unmarshaler           = (*IntTupleSet)(nil)
+
+	_ encoding.TextMarshaler     = IntTupleInterval{}
+	_ encoding.BinaryUnmarshaler = (*IntTupleInterval)(nil)
+	_ json.Unmarshaler           = (*IntTupleInterval)(nil)
+)
+
+func TestIntTupleMarshal(t *testing.T) {
+	for _, x := range []IntTuple{{}, {0}, {-7}, {1, 2}, {-1, 0, 1 << 40}} {
+		text, _ := x.MarshalText()
+		var xt IntTuple
+		if err := xt.UnmarshalText(text); err != nil || xt.Compare(x) != 0 || xt.Size() != x.Size() {
+			t.Errorf("text round trip of %s: got %s (err %v)", x, xt, err)
+		}
+
+		data, _ := json.Marshal(x)
+		var xj IntTuple
+		if err := json.Unmarshal(data, &xj); err != nil || xj.Compare(x) != 0 || xj.Size() != x.Size() {
+			t.Errorf("JSON round trip of %s via %s: got %s (err %v)", x, data, xj, err)
+		}
+
+		bin, _ := x.MarshalBinary()
+		var xb IntTuple
+		if err := xb.UnmarshalBinary(bin); err != nil || xb.Compare(x) != 0 || xb.Size() != x.Size() {
+			t.Errorf("binary round trip of %s: got %s (err %v)", x, xb, err)
+		}
+	}
+}
+
+func TestIntTupleMarshalFormat(t *testing.T) {
+	x := IntTuple{1, -2}
+	if data, _ := json.Marshal(x); string(data) != "[1,-2]" {
+		t.Errorf("expected JSON [1,-2] but got %s", data)
+	}
+	bin, _ := x.MarshalBinary()
+	if want := []byte{2, 2, 3}; string(want) != string(bin) {
+		t.Errorf("expected binary %v but got %v", want, bin)
+	}
+	m, _ := json.Marshal(map[string]IntTuple{"a": {1, 2}})
+	if want := `{"a":[1,2]}`; want != string(m) {
+		t.Errorf("expected JSON %s but got %s", want, m)
+	}
+}
+
+func TestIntTupleUnmarshalDim(t *testing.T) {
+	x := NewIntTuple(2).Identity().(IntTuple)
+	if err := json.Unmarshal([]byte("[1,2,3]"), &x); err == nil {
+		t.Errorf("expected dimension mismatch error but got %s", x)
+	}
+	if err := x.UnmarshalText([]byte("(4,5)")); err != nil || x.Compare(IntTuple{4, 5}) != 0 {
+		t.Errorf("expected (4,5) but got %s (err %v)", x, err)
+	}
+}
+
+func TestIntTupleUnmarshalBinaryError(t *testing.T) {
+	var x IntTuple
+	for _, data := range [][]byte{{}, {2, 2}, {1, 0x80}, {1, 2, 3}} {
+		if err := x.UnmarshalBinary(data); err == nil {
+			t.Errorf("expected error unmarshaling %v but got %s", data, x)
+		}
+	}
+}
+
+func TestIntTupleSetMarshal(t *testing.T) {
+	s := NewIntTuple(3)
+	data, _ := json.Marshal(s)
+	if want := `"ℤxℤxℤ"`; want != string(data) {
+		t.Errorf("expected JSON %s but got %s", want, data)
+	}
+	var sj IntTupleSet
+	if err := json.Unmarshal([]byte(`"Z^3"`), &sj); err != nil || sj != s {
+		t.Errorf("expected %s but got %s (err %v)", s.Name(), sj.Name(), err)
+	}
+	bin, _ := s.MarshalBinary()
+	var sb IntTupleSet
+	if err := sb.UnmarshalBinary(bin); err != nil || sb != s {
+		t.Errorf("expected %s but got %s (err %v)", s.Name(), sb.Name(), err)
+	}
+}
+
+func TestIntTupleSetUnmarshalBinaryError(t *testing.T) {
+	var s IntTupleSet
+	for _, data := range [][]byte{
+		{},
+		{0x80},
+		{1, 0},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, // 1<<64 - 1
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, // overflows uint64
+	} {
+		if err := s.UnmarshalBinary(data); err == nil {
+			t.Errorf("expected error unmarshaling %v but got %s", data, s.Name())
+		}
+	}
+}
+
+func TestIntTupleIntervalMarshal(t *testing.T) {
+	s := NewIntTuple(2)
+	iv := s.Interval(s.Tuple(0, 1), s.Tuple(2, 3)).(IntTupleInterval)
+	check := func(kind string, got IntTupleInterval, err error) {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", kind, err)
+			return
+		}
+		if got.Name() != iv.Name() || got.Set.(IntTupleSet) != s {
+			t.Errorf("%s: expected %s but got %s", kind, iv.Name(), got.Name())
+		}
+		if w, g := len(iv.Slice()), len(got.Slice()); w != g {
+			t.Errorf("%s: expected %d Elems but got %d", kind, w, g)
+		}
+	}
+
+	text, _ := iv.MarshalText()
+	var it IntTupleInterval
+	check("text", it, it.UnmarshalText(text))
+
+	data, _ := json.Marshal(iv)
+	if want := `{"lo":[0,1],"hi":[2,3]}`; want != string(data) {
+		t.Errorf("expected JSON %s but got %s", want, data)
+	}
+	var ij IntTupleInterval
+	check("JSON", ij, json.Unmarshal(data, &ij))
+
+	bin, _ := iv.MarshalBinary()
+	var ib IntTupleInterval
+	check("binary", ib, ib.UnmarshalBinary(bin))
+}
+
+func TestIntTupleIntervalUnmarshalDim(t *testing.T) {
+	var iv IntTupleInterval
+	if err := json.Unmarshal([]byte(`{"lo":[0,1],"hi":[2]}`), &iv); err == nil {
+		t.Errorf("expected dimension mismatch error")
+	}
+	if err := json.Unmarshal([]byte(`{"lo":[0,1]}`), &iv); err == nil {
+		t.Errorf("expected missing bounds error")
+	}
+	s := NewIntTuple(3)
+	iv = s.Interval(s.Tuple(0, 0, 0), s.Tuple(1, 1, 1)).(IntTupleInterval)
+	if err := iv.UnmarshalText([]byte("(0,0)≤..≤(1,1)")); err == nil {
+		t.Errorf("expected dimension mismatch error")
+	}
+}
@@ -0,0 +1,342 @@
+package set
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+)
+
+// MatrixSet is the set of integer matrices of a fixed shape, ℤ^{m×n},
+// which forms a torsion-free abelian group of rank m·n under Add.
+//
+// Matrices also have the ring operations of integer matrices, e.g. Mul
+// and Inverse over ℤ, and act on the IntTuples of ℤ^n by Apply.
+type MatrixSet struct {
+	m, n int
+}
+
+// NewMatrix returns the set of m×n integer matrices.
+func NewMatrix(m, n int) MatrixSet {
+	if m < 0 || n < 0 {
+		log.Fatalf("cannot create %d×%d matrices", m, n)
+	}
+	return MatrixSet{m: m, n: n}
+}
+
+// Shape returns the number of rows m and columns n of the matrices.
+func (s MatrixSet) Shape() (m, n int) {
+	return s.m, s.n
+}
+
+// Matrix returns the matrix with the rows, which must be m tuples of size n.
+func (s MatrixSet) Matrix(rows ...IntTuple) Matrix {
+	if len(rows) != s.m {
+		log.Fatalf("cannot create matrix of %s with %d rows", s.Name(), len(rows))
+	}
+	a := Matrix{m: s.m, n: s.n, a: make([]int, 0, s.m*s.n)}
+	for _, row := range rows {
+		if row.Size() != s.n {
+			log.Fatal(MismatchDimErr{Dim1: row.Size(), Dim2: s.n})
+		}
+		a.a = append(a.a, row...)
+	}
+	return a
+}
+
+// IdentityMatrix returns the n×n identity matrix, the identity of Mul.
+func IdentityMatrix(n int) Matrix {
+	a := Matrix{m: n, n: n, a: make([]int, n*n)}
+	for i := 0; i < n; i++ {
+		a.a[i*n+i] = 1
+	}
+	return a
+}
+
+// Identity returns the m×n zero matrix, the identity of the set.
+func (s MatrixSet) Identity() Elem {
+	return Matrix{m: s.m, n: s.n, a: make([]int, s.m*s.n)}
+}
+
+// IsIn returns true if x ∈ s.
+func (s MatrixSet) IsIn(x Elem) bool {
+	xElem, ok := x.(Matrix)
+	return ok && xElem.m == s.m && xElem.n == s.n
+}
+
+// Name returns the formal name of the set, e.g. ℤ^{2×3}.
+func (s MatrixSet) Name() string {
+	return fmt.Sprintf("ℤ^{%d×%d}", s.m, s.n)
+}
+
+// Rank returns the torsion-free rank of the set, i.e. m·n.
+func (s MatrixSet) Rank() int {
+	return s.m * s.n
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s MatrixSet) Add(x, y Elem) Elem {
+	return s.combine(x, y, 1)
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s MatrixSet) Sub(x, y Elem) Elem {
+	return s.combine(x, y, -1)
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+//
+// For the inverse of x under Mul, see Matrix.Inverse.
+func (s MatrixSet) Inverse(x Elem) Elem {
+	return s.combine(s.Identity(), x, -1)
+}
+
+// combine returns x + sign·y, which have the shape of s.
+func (s MatrixSet) combine(x, y Elem, sign int) Matrix {
+	xElem, yElem := x.(Matrix), y.(Matrix)
+	if !s.IsIn(xElem) || !s.IsIn(yElem) {
+		log.Fatalf("cannot add %d×%d and %d×%d matrices in %s", xElem.m, xElem.n, yElem.m, yElem.n, s.Name())
+	}
+	z := Matrix{m: s.m, n: s.n, a: make([]int, len(xElem.a))}
+	for i := range z.a {
+		z.a[i] = xElem.a[i] + sign*yElem.a[i]
+	}
+	return z
+}
+
+// Matrix is an Elem of a MatrixSet, an m×n integer matrix.
+type Matrix struct {
+	m, n int
+	a    []int // entries in row-major order, not modified after creation.
+}
+
+// Shape returns the number of rows m and columns n of a.
+func (a Matrix) Shape() (m, n int) {
+	return a.m, a.n
+}
+
+// At returns the entry of a at row i and column j.
+func (a Matrix) At(i, j int) int {
+	if i < 0 || i >= a.m || j < 0 || j >= a.n {
+		log.Fatalf("entry (%d,%d) out of range of %d×%d matrix", i, j, a.m, a.n)
+	}
+	return a.a[i*a.n+j]
+}
+
+// Row returns the i-th row of a.
+func (a Matrix) Row(i int) IntTuple {
+	return IntTuple(a.a[i*a.n : (i+1)*a.n]).Clone()
+}
+
+// Col returns the j-th column of a.
+func (a Matrix) Col(j int) IntTuple {
+	col := make(IntTuple, a.m)
+	for i := range col {
+		col[i] = a.At(i, j)
+	}
+	return col
+}
+
+// Rows returns the rows of a, e.g. for lattice.SmithNormalForm.
+func (a Matrix) Rows() []IntTuple {
+	rows := make([]IntTuple, a.m)
+	for i := range rows {
+		rows[i] = a.Row(i)
+	}
+	return rows
+}
+
+// Mul returns the matrix product a·b, where b has as many rows as a has columns.
+func (a Matrix) Mul(b Matrix) Matrix {
+	if a.n != b.m {
+		log.Fatalf("cannot multiply %d×%d and %d×%d matrices", a.m, a.n, b.m, b.n)
+	}
+	c := Matrix{m: a.m, n: b.n, a: make([]int, a.m*b.n)}
+	for i := 0; i < a.m; i++ {
+		for k := 0; k < a.n; k++ {
+			if aik := a.a[i*a.n+k]; aik != 0 {
+				for j := 0; j < b.n; j++ {
+					c.a[i*c.n+j] += aik * b.a[k*b.n+j]
+				}
+			}
+		}
+	}
+	return c
+}
+
+// Apply returns the tuple a·x, where x has a coordinate for each column of a.
+func (a Matrix) Apply(x IntTuple) IntTuple {
+	if x.Size() != a.n {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: a.n})
+	}
+	y := make(IntTuple, a.m)
+	for i := range y {
+		for j, v := range x {
+			y[i] += a.a[i*a.n+j] * v
+		}
+	}
+	return y
+}
+
+// Transpose returns the n×m transpose of a.
+func (a Matrix) Transpose() Matrix {
+	t := Matrix{m: a.n, n: a.m, a: make([]int, len(a.a))}
+	for i := 0; i < a.m; i++ {
+		for j := 0; j < a.n; j++ {
+			t.a[j*t.n+i] = a.a[i*a.n+j]
+		}
+	}
+	return t
+}
+
+// Det returns the determinant of the square matrix a,
+// which must fit in an int.
+//
+// It uses fraction-free (Bareiss) elimination, so the
+// intermediate entries are exact integers.
+func (a Matrix) Det() int {
+	d := a.det()
+	if !d.IsInt64() || d.Int64() < int64(minInt) || d.Int64() > int64(maxInt) {
+		log.Fatalf("determinant %v of %d×%d matrix overflows int", d, a.m, a.n)
+	}
+	return int(d.Int64())
+}
+
+// det returns the determinant of the square matrix a. The intermediate
+// entries of the elimination may overflow int, so they are big.Ints.
+func (a Matrix) det() *big.Int {
+	if a.m != a.n {
+		log.Fatalf("cannot compute determinant of %d×%d matrix", a.m, a.n)
+	}
+	n := a.n
+	if n == 0 {
+		return big.NewInt(1)
+	}
+	b := make([]*big.Int, len(a.a))
+	for i, v := range a.a {
+		b[i] = big.NewInt(int64(v))
+	}
+	negate, prev := false, big.NewInt(1)
+	tmp := new(big.Int)
+	for k := 0; k < n-1; k++ {
+		if b[k*n+k].Sign() == 0 {
+			p := k + 1
+			for p < n && b[p*n+k].Sign() == 0 {
+				p++
+			}
+			if p == n {
+				return new(big.Int)
+			}
+			for j := 0; j < n; j++ {
+				b[k*n+j], b[p*n+j] = b[p*n+j], b[k*n+j]
+			}
+			negate = !negate
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				b[i*n+j].Mul(b[i*n+j], b[k*n+k])
+				b[i*n+j].Sub(b[i*n+j], tmp.Mul(b[i*n+k], b[k*n+j]))
+				b[i*n+j].Quo(b[i*n+j], prev) // exact division.
+			}
+		}
+		prev = b[k*n+k]
+	}
+	if negate {
+		return b[n*n-1].Neg(b[n*n-1])
+	}
+	return b[n*n-1]
+}
+
+// IsUnimodular returns true if a is a square matrix
+// with determinant ±1, i.e. a is invertible over ℤ.
+func (a Matrix) IsUnimodular() bool {
+	if a.m != a.n {
+		return false
+	}
+	return a.det().CmpAbs(big.NewInt(1)) == 0
+}
+
+// Inverse returns the inverse of a under Mul over ℤ, and false
+// if a is not unimodular and has no integer inverse.
+func (a Matrix) Inverse() (Matrix, bool) {
+	if !a.IsUnimodular() {
+		return Matrix{}, false
+	}
+	// Gauss-Jordan elimination of [a | I] over ℚ.
+	n := a.n
+	aug := make([][]*big.Rat, n)
+	for i := range aug {
+		aug[i] = make([]*big.Rat, 2*n)
+		for j := 0; j < n; j++ {
+			aug[i][j] = big.NewRat(int64(a.a[i*n+j]), 1)
+			aug[i][n+j] = new(big.Rat)
+		}
+		aug[i][n+i].SetInt64(1)
+	}
+	tmp := new(big.Rat)
+	for k := 0; k < n; k++ {
+		p := k
+		for aug[p][k].Sign() == 0 {
+			p++
+		}
+		aug[k], aug[p] = aug[p], aug[k]
+		pivot := new(big.Rat).Inv(aug[k][k])
+		for j := range aug[k] {
+			aug[k][j].Mul(aug[k][j], pivot)
+		}
+		for i := 0; i < n; i++ {
+			if i == k || aug[i][k].Sign() == 0 {
+				continue
+			}
+			f := new(big.Rat).Set(aug[i][k])
+			for j := range aug[i] {
+				aug[i][j].Sub(aug[i][j], tmp.Mul(f, aug[k][j]))
+			}
+		}
+	}
+	inv := Matrix{m: n, n: n, a: make([]int, n*n)}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			v := aug[i][n+j].Num() // integral since det = ±1.
+			if !v.IsInt64() || v.Int64() < int64(minInt) || v.Int64() > int64(maxInt) {
+				log.Fatalf("entry %v of the inverse of %s overflows int", v, a)
+			}
+			inv.a[i*n+j] = int(v.Int64())
+		}
+	}
+	return inv, true
+}
+
+// String returns the matrix as a tuple of rows, e.g. ((1,2),(3,4)).
+func (a Matrix) String() string {
+	rows := make([]string, a.m)
+	for i := range rows {
+		row := a.Row(i)
+		if row.Size() == 1 {
+			rows[i] = "(" + row.String() + ")"
+		} else {
+			rows[i] = row.String()
+		}
+	}
+	return "(" + strings.Join(rows, ",") + ")"
+}
+
+// Compare returns 0 if a == x, -ve int if a < x, +ve int if a > x,
+// comparing the entries lexicographically in row-major order.
+func (a Matrix) Compare(x Elem) int {
+	b := x.(Matrix)
+	if a.m != b.m || a.n != b.n {
+		log.Fatalf("cannot compare %d×%d and %d×%d matrices", a.m, a.n, b.m, b.n)
+	}
+	return IntTuple(a.a).Compare(IntTuple(b.a))
+}
+
+// Key returns the binary encoding of the shape and entries of a as a string.
+func (a Matrix) Key() string {
+	return IntTuple{a.m, a.n}.Key() + IntTuple(a.a).Key()
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of a.
+func (a Matrix) Hash() uint64 {
+	return hashKey(a.Key())
+}
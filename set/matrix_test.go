@@ -0,0 +1,96 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestMatrix(t *testing.T) {
+	s := NewMatrix(2, 3)
+	if want, got := "ℤ^{2×3}", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	a := s.Matrix(IntTuple{1, 2, 3}, IntTuple{4, 5, 6})
+	b := s.Matrix(IntTuple{0, -1, 0}, IntTuple{1, 1, 1})
+	if want, got := "((1,1,3),(5,6,7))", s.Add(a, b).String(); want != got {
+		t.Errorf("expected a + b = %s but got %s", want, got)
+	}
+	if want, got := s.Identity(), s.Add(a, s.Inverse(a)); want.Compare(got) != 0 || Key(want) != Key(got) {
+		t.Errorf("expected a + -a = %v but got %v", want, got)
+	}
+	if want, got := "((1,4),(2,5),(3,6))", a.Transpose().String(); want != got {
+		t.Errorf("expected transpose %s but got %s", want, got)
+	}
+	if want, got := "((14,32),(32,77))", a.Mul(a.Transpose()).String(); want != got {
+		t.Errorf("expected a·aᵀ = %s but got %s", want, got)
+	}
+	if want, got := "(14,32)", a.Apply(IntTuple{1, 2, 3}).String(); want != got {
+		t.Errorf("expected a·(1,2,3) = %s but got %s", want, got)
+	}
+	if want, got := "(3,6)", a.Col(2).String(); want != got {
+		t.Errorf("expected column %s but got %s", want, got)
+	}
+}
+
+func TestMatrixDet(t *testing.T) {
+	s := NewMatrix(3, 3)
+	for _, tc := range []struct {
+		a   Matrix
+		det int
+	}{
+		{s.Matrix(IntTuple{2, 3, 1}, IntTuple{1, 2, 1}, IntTuple{1, 1, 1}), 1},
+		{s.Matrix(IntTuple{0, 1, 0}, IntTuple{1, 0, 0}, IntTuple{0, 0, 1}), -1},
+		{s.Matrix(IntTuple{1, 2, 3}, IntTuple{4, 5, 6}, IntTuple{7, 8, 9}), 0},
+		{s.Matrix(IntTuple{2, -3, 1}, IntTuple{2, 0, -1}, IntTuple{1, 4, 5}), 49},
+		{IdentityMatrix(3), 1},
+	} {
+		if want, got := tc.det, tc.a.Det(); want != got {
+			t.Errorf("expected det %v = %d but got %d", tc.a, want, got)
+		}
+	}
+	if want, got := 7, NewMatrix(1, 1).Matrix(IntTuple{7}).Det(); want != got {
+		t.Errorf("expected det (7) = %d but got %d", want, got)
+	}
+}
+
+// TestMatrixDetLarge checks a unimodular matrix whose intermediate
+// entries in the elimination overflow int.
+func TestMatrixDetLarge(t *testing.T) {
+	a := NewMatrix(4, 4).Matrix(
+		IntTuple{-13289751, -187544, -569870, -1225},
+		IntTuple{-1798337, -4918009, -1611026, -3487},
+		IntTuple{1949213, 5661262, 1849849, 4004},
+		IntTuple{486, 1414, 462, 1},
+	)
+	if want, got := 1, a.Det(); want != got {
+		t.Errorf("expected det %v = %d but got %d", a, want, got)
+	}
+	inv, ok := a.Inverse()
+	if !ok {
+		t.Fatalf("expected %v to have an inverse", a)
+	}
+	if want, got := IdentityMatrix(4), a.Mul(inv); want.Compare(got) != 0 {
+		t.Errorf("expected a·a⁻¹ = %v but got %v", want, got)
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	s := NewMatrix(3, 3)
+	a := s.Matrix(IntTuple{2, 3, 1}, IntTuple{1, 2, 1}, IntTuple{1, 1, 1})
+	if !a.IsUnimodular() {
+		t.Fatalf("expected %v to be unimodular", a)
+	}
+	inv, ok := a.Inverse()
+	if !ok {
+		t.Fatalf("expected %v to have an inverse", a)
+	}
+	if want, got := IdentityMatrix(3), a.Mul(inv); want.Compare(got) != 0 {
+		t.Errorf("expected a·a⁻¹ = %v but got %v", want, got)
+	}
+	if want, got := IdentityMatrix(3), inv.Mul(a); want.Compare(got) != 0 {
+		t.Errorf("expected a⁻¹·a = %v but got %v", want, got)
+	}
+	b := NewMatrix(2, 2).Matrix(IntTuple{2, 0}, IntTuple{0, 1})
+	if _, ok := b.Inverse(); ok || b.IsUnimodular() {
+		t.Errorf("expected %v not to be invertible over ℤ", b)
+	}
+}
@@ -0,0 +1,146 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+// ModSet is the set of integers modulo n (ℤ/n), which forms
+// the cyclic group of order n under Add.
+//
+// Unlike IntTupleSet, ModSet is finite and can be enumerated,
+// but it is not ordered compatibly with Add.
+type ModSet int
+
+// NewMod returns the set of integers modulo n, where n > 0.
+func NewMod(n int) ModSet {
+	if n <= 0 {
+		log.Fatalf("cannot create ℤ/%d: modulus must be positive", n)
+	}
+	return ModSet(n)
+}
+
+// Modulus returns the modulus n of ℤ/n.
+func (s ModSet) Modulus() int {
+	return int(s)
+}
+
+// Elem returns the residue of v modulo n.
+func (s ModSet) Elem(v int) ModInt {
+	r := v % s.Modulus()
+	if r < 0 {
+		r += s.Modulus()
+	}
+	return ModInt(r)
+}
+
+// Identity returns 0, the identity of the set.
+func (s ModSet) Identity() Elem {
+	return ModInt(0)
+}
+
+// IsIn returns true if x ∈ s, i.e. x is a residue in [0, n).
+func (s ModSet) IsIn(x Elem) bool {
+	xElem, ok := x.(ModInt)
+	return ok && 0 <= xElem && int(xElem) < s.Modulus()
+}
+
+// Name returns the formal name of the set, e.g. ℤ/6.
+func (s ModSet) Name() string {
+	return fmt.Sprintf("ℤ/%d", s.Modulus())
+}
+
+// Add is the + binary operation modulo n. It returns x + y.
+func (s ModSet) Add(x, y Elem) Elem {
+	return s.Elem(int(x.(ModInt)) + int(y.(ModInt)))
+}
+
+// Sub is the - binary operation modulo n. It returns x - y.
+func (s ModSet) Sub(x, y Elem) Elem {
+	return s.Elem(int(x.(ModInt)) - int(y.(ModInt)))
+}
+
+// Inverse returns the additive inverse of x modulo n, i.e. -x.
+func (s ModSet) Inverse(x Elem) Elem {
+	return s.Elem(-int(x.(ModInt)))
+}
+
+// Cardinality returns n, the number of elements of ℤ/n.
+func (s ModSet) Cardinality() int {
+	return s.Modulus()
+}
+
+// Enumerate creates an iterator for looping over the residues 0..n-1.
+func (s ModSet) Enumerate() Nexter {
+	return &ModIter{n: s.Modulus()}
+}
+
+// Slice returns the residues 0..n-1 as a slice.
+func (s ModSet) Slice() []Elem {
+	xs := make([]Elem, s.Modulus())
+	for i := range xs {
+		xs[i] = ModInt(i)
+	}
+	return xs
+}
+
+// Sequence returns an iterator over the residues 0..n-1,
+// the same as Enumerate.
+func (s ModSet) Sequence() Nexter {
+	return s.Enumerate()
+}
+
+// ParseElem parses an integer into its residue modulo n.
+func (s ModSet) ParseElem(text string) (Elem, error) {
+	x, err := ParseIntTuple(text)
+	if err != nil {
+		return nil, err
+	}
+	if x.Size() != 1 {
+		return nil, ParseError{Text: text, Msg: fmt.Sprintf("expecting integer but got tuple/%d", x.Size())}
+	}
+	return s.Elem(x[0]), nil
+}
+
+// ModIter is an iterator of the residues of ℤ/n.
+type ModIter struct {
+	n, curr int
+}
+
+// Next returns the next residue, and indicates
+// if there are more residues with more.
+func (n *ModIter) Next() (next Elem, more bool) {
+	next = ModInt(n.curr)
+	if n.curr < n.n-1 {
+		n.curr++
+		return next, true
+	}
+	return next, false
+}
+
+// ModInt is an Elem of a ModSet, a residue in [0, n).
+type ModInt int
+
+// String returns the residue as an integer.
+func (e ModInt) String() string {
+	return strconv.Itoa(int(e))
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x,
+// comparing the residues as integers.
+func (e ModInt) Compare(x Elem) int {
+	return compareInts(int(e), int(x.(ModInt)))
+}
+
+// Key returns the binary encoding of the residue e as a string.
+func (e ModInt) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	return string(tmp[:binary.PutVarint(tmp[:], int64(e))])
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of e.
+func (e ModInt) Hash() uint64 {
+	return hashKey(e.Key())
+}
@@ -0,0 +1,63 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestMod(t *testing.T) {
+	s := NewMod(6)
+	if want, got := "ℤ/6", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := ModInt(3), s.Add(ModInt(4), ModInt(5)); want != got {
+		t.Errorf("expected 4+5 = %v but got %v", want, got)
+	}
+	if want, got := ModInt(5), s.Sub(ModInt(1), ModInt(2)); want != got {
+		t.Errorf("expected 1-2 = %v but got %v", want, got)
+	}
+	if want, got := ModInt(2), s.Inverse(ModInt(4)); want != got {
+		t.Errorf("expected -4 = %v but got %v", want, got)
+	}
+	if want, got := ModInt(4), s.Elem(-14); want != got {
+		t.Errorf("expected -14 = %v but got %v", want, got)
+	}
+	if s.IsIn(ModInt(6)) || s.IsIn(ModInt(-1)) || !s.IsIn(ModInt(0)) || s.IsIn(IntTuple{1}) {
+		t.Errorf("unexpected membership of %s", s.Name())
+	}
+}
+
+func TestModEnumerate(t *testing.T) {
+	s := NewMod(3)
+	var got []Elem
+	e := s.Enumerate()
+	for {
+		next, more := e.Next()
+		got = append(got, next)
+		if !more {
+			break
+		}
+	}
+	want := s.Slice()
+	if len(want) != s.Cardinality() || len(got) != len(want) {
+		t.Fatalf("expected %d elements but got %v and %v", s.Cardinality(), want, got)
+	}
+	for i := range want {
+		if want[i].Compare(got[i]) != 0 || want[i].Compare(ModInt(i)) != 0 {
+			t.Errorf("expected element %d to be %d but got %v and %v", i, i, want[i], got[i])
+		}
+	}
+}
+
+func TestModParse(t *testing.T) {
+	s := NewMod(5)
+	x, err := ParseElem(s, "-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := ModInt(3), x; want != got {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+	if _, err := ParseElem(s, "(1,2)"); err == nil {
+		t.Errorf("expected error parsing tuple in %s", s.Name())
+	}
+}
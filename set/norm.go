@@ -0,0 +1,181 @@
+package set
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Metric is a distance function between two Elems of a set.
+type Metric func(x, y Elem) int
+
+// normKind is the coordinate-wise shape of a Norm.
+type normKind int
+
+const (
+	l1Norm   normKind = iota // Σ wᵢ|xᵢ|
+	l2SqNorm                 // Σ wᵢxᵢ²
+	lInfNorm                 // max wᵢ|xᵢ|
+)
+
+// Norm is a coordinate-wise norm on IntTuple.
+//
+// A Norm is either a sum (L1, L2²) or a maximum (L∞) of the
+// per-coordinate costs wᵢ·f(xᵢ), where wᵢ are positive weights
+// (all 1 unless created by Weighted).
+//
+// L2² is the squared Euclidean norm, which keeps the norm integral;
+// a ball of radius r in L2² is the Euclidean ball of radius √r.
+type Norm struct {
+	kind    normKind
+	weights []int
+}
+
+var (
+	// L1 is the taxicab norm ‖x‖₁ = Σ|xᵢ|.
+	L1 = Norm{kind: l1Norm}
+
+	// L2Sq is the squared Euclidean norm ‖x‖₂² = Σxᵢ².
+	L2Sq = Norm{kind: l2SqNorm}
+
+	// LInf is the maximum norm ‖x‖∞ = max|xᵢ|.
+	LInf = Norm{kind: lInfNorm}
+)
+
+// Weighted returns a copy of n where the cost of the i-th
+// coordinate is multiplied by w[i].
+//
+// All weights must be positive.
+func Weighted(n Norm, w ...int) Norm {
+	for i := range w {
+		if w[i] <= 0 {
+			log.Fatalf("cannot create weighted %s norm: weight w[%d]=%d is not positive", n.Name(), i, w[i])
+		}
+	}
+	weights := make([]int, len(w))
+	copy(weights, w)
+	return Norm{kind: n.kind, weights: weights}
+}
+
+// Name returns the formal name of the norm.
+func (n Norm) Name() string {
+	var name string
+	switch n.kind {
+	case l1Norm:
+		name = "L1"
+	case l2SqNorm:
+		name = "L2²"
+	case lInfNorm:
+		name = "L∞"
+	}
+	if n.weights == nil {
+		return name
+	}
+	w := make([]string, len(n.weights))
+	for i := range w {
+		w[i] = fmt.Sprintf("%d", n.weights[i])
+	}
+	return fmt.Sprintf("%s[%s]", name, strings.Join(w, ","))
+}
+
+// Of returns the norm ‖x‖ of the IntTuple x.
+func (n Norm) Of(x Elem) int {
+	xElem := x.(IntTuple)
+	n.checkDim(xElem.Size())
+	norm := 0
+	for i, v := range xElem {
+		norm = n.combine(norm, n.cost(i, v))
+	}
+	return norm
+}
+
+// Dist returns the distance d(x, y) = ‖x - y‖ between the IntTuples x and y.
+func (n Norm) Dist(x, y Elem) int {
+	xElem, yElem := x.(IntTuple), y.(IntTuple)
+	if xElem.Size() != yElem.Size() {
+		log.Fatal(MismatchDimErr{Dim1: xElem.Size(), Dim2: yElem.Size()})
+	}
+	n.checkDim(xElem.Size())
+	dist := 0
+	for i := range xElem {
+		dist = n.combine(dist, n.cost(i, xElem[i]-yElem[i]))
+	}
+	return dist
+}
+
+// Metric returns the distance function induced by n.
+func (n Norm) Metric() Metric {
+	return n.Dist
+}
+
+func (n Norm) checkDim(size int) {
+	if n.weights != nil && len(n.weights) != size {
+		log.Fatal(MismatchDimErr{Dim1: size, Dim2: len(n.weights)})
+	}
+}
+
+// weight returns the weight of the i-th coordinate.
+func (n Norm) weight(i int) int {
+	if n.weights == nil {
+		return 1
+	}
+	return n.weights[i]
+}
+
+// cost returns the contribution of the value v at the i-th coordinate.
+func (n Norm) cost(i, v int) int {
+	if v < 0 {
+		v = -v
+	}
+	if n.kind == l2SqNorm {
+		return n.weight(i) * v * v
+	}
+	return n.weight(i) * v
+}
+
+// combine accumulates the coordinate cost c into the partial norm acc.
+func (n Norm) combine(acc, c int) int {
+	if n.kind == lInfNorm {
+		if c > acc {
+			return c
+		}
+		return acc
+	}
+	return acc + c
+}
+
+// budget returns the cost still available to the remaining
+// coordinates in a ball of radius r, given the partial norm acc.
+func (n Norm) budget(r, acc int) int {
+	if n.kind == lInfNorm {
+		return r
+	}
+	return r - acc
+}
+
+// bound returns the largest v ≥ 0 such that the cost of v at
+// the i-th coordinate is within budget b, or -1 if none is.
+func (n Norm) bound(i, b int) int {
+	if b < 0 {
+		return -1
+	}
+	w := n.weight(i)
+	if n.kind != l2SqNorm {
+		return b / w
+	}
+	return isqrt(b / w)
+}
+
+// isqrt returns ⌊√q⌋ for q ≥ 0.
+func isqrt(q int) int {
+	v := 0
+	for lo, hi := 1, q; lo <= hi; {
+		mid := lo + (hi-lo)/2
+		if mid <= q/mid {
+			v, lo = mid, mid+1
+		} else {
+			hi = mid - 1
+		}
+	}
+	return v
+}
@@ -0,0 +1,61 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestNorm(t *testing.T) {
+	s := NewIntTuple(3)
+	x := s.Tuple(1, -2, 3)
+	tests := []struct {
+		norm Norm
+		want int
+	}{
+		{L1, 6},
+		{L2Sq, 14},
+		{LInf, 3},
+		{Weighted(L1, 1, 2, 3), 14},
+		{Weighted(L2Sq, 1, 2, 3), 36},
+		{Weighted(LInf, 3, 2, 1), 4},
+	}
+	for _, tt := range tests {
+		if got := tt.norm.Of(x); tt.want != got {
+			t.Errorf("expected %s norm of %s to be %d but got %d", tt.norm.Name(), x, tt.want, got)
+		}
+	}
+}
+
+func TestNormDist(t *testing.T) {
+	s := NewIntTuple(2)
+	x, y := s.Tuple(1, 2), s.Tuple(4, -2)
+	if want, got := 7, L1.Dist(x, y); want != got {
+		t.Errorf("expected L1 distance %d but got %d", want, got)
+	}
+	if want, got := 25, L2Sq.Metric()(x, y); want != got {
+		t.Errorf("expected L2² distance %d but got %d", want, got)
+	}
+	if want, got := L1.Dist(x, y), L1.Dist(y, x); want != got {
+		t.Errorf("expected distance to be symmetric: %d != %d", want, got)
+	}
+	if want, got := 0, LInf.Dist(x, x); want != got {
+		t.Errorf("expected distance to self to be %d but got %d", want, got)
+	}
+}
+
+func TestNormName(t *testing.T) {
+	if want, got := "L1", L1.Name(); want != got {
+		t.Errorf("expected name %s but got %s", want, got)
+	}
+	if want, got := "L∞[1,2]", Weighted(LInf, 1, 2).Name(); want != got {
+		t.Errorf("expected name %s but got %s", want, got)
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	for q := 0; q < 1000; q++ {
+		v := isqrt(q)
+		if v*v > q || (v+1)*(v+1) <= q {
+			t.Errorf("isqrt(%d) = %d is not ⌊√%d⌋", q, v, q)
+		}
+	}
+}
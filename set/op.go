@@ -0,0 +1,112 @@
+package set
+
+import (
+	"log"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Operation describes a binary operation for printing groups,
+// e.g. 〈ℤxℤ, +, (0,0)〉.
+type Operation struct {
+	// Name is the name of the operation, e.g. "addition".
+	Name string
+
+	// Symbol is the infix symbol of the operation, e.g. +, · or ⊕.
+	Symbol string
+
+	// Identity is the notation of the identity, e.g. 0 or e.
+	// If empty, the String of the identity Elem is used.
+	Identity string
+}
+
+var ops = struct {
+	sync.RWMutex
+	m map[string]Operation
+}{m: make(map[string]Operation)}
+
+// closureName matches the names the runtime gives to function literals,
+// e.g. main.main.func1 or main.init.func2.1.
+var closureName = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+// opName returns the qualified name of the function of op, e.g.
+// github.com/nickng/abelian/set.IntTupleSet.Add-fm for the method value
+// s.Add, which is the same for every receiver s.
+func opName(op BinOp) string {
+	if op == nil {
+		return ""
+	}
+	if f := runtime.FuncForPC(reflect.ValueOf(op).Pointer()); f != nil {
+		return f.Name()
+	}
+	return ""
+}
+
+// RegisterOp registers the description of the operation op, which must
+// be a named function or a method value.
+//
+// Operations are identified by the qualified names of their functions,
+// so registering a method value such as s.Add describes the method for
+// all receivers. Function literals cannot be told apart by name, so
+// describe their groups with abelian.Group.Describe instead.
+func RegisterOp(op BinOp, o Operation) {
+	name := opName(op)
+	if name == "" || closureName.MatchString(name) {
+		log.Fatalf("cannot register operation %q: not a named function or method", name)
+	}
+	ops.Lock()
+	defer ops.Unlock()
+	ops.m[name] = o
+}
+
+// LookupOp returns the description of the operation op
+// if it has been registered.
+func LookupOp(op BinOp) (Operation, bool) {
+	name := opName(op)
+	if name == "" || closureName.MatchString(name) {
+		return Operation{}, false
+	}
+	ops.RLock()
+	defer ops.RUnlock()
+	o, ok := ops.m[name]
+	return o, ok
+}
+
+// DescribeOp returns the description of the operation op. If op is not
+// registered, the Symbol and Name are the name of its function,
+// e.g. plusOne for a function func plusOne(x, y Elem) Elem, or
+// ModSet.Sub for a method value s.Sub.
+func DescribeOp(op BinOp) Operation {
+	if o, ok := LookupOp(op); ok {
+		return o
+	}
+	name := opName(op)
+	if name == "" {
+		name = "op"
+	}
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[i+1:] // strip the package name.
+	}
+	name = strings.TrimSuffix(name, "-fm") // method values.
+	return Operation{Name: name, Symbol: name}
+}
+
+func init() {
+	add := Operation{Name: "addition", Symbol: "+"}
+	RegisterOp(IntTupleSet(0).Add, add)
+	RegisterOp(Int2Set{}.Add, add)
+	RegisterOp(Int3Set{}.Add, add)
+	RegisterOp(SparseIntTupleSet(0).Add, add)
+	RegisterOp(FreeAbelianSet{}.Add, add)
+	RegisterOp(LocalSet{}.Add, add)
+	RegisterOp(PolySet(1).Add, add)
+	RegisterOp(MatrixSet{}.Add, add)
+	RegisterOp(ModSet(1).Add, Operation{Name: "addition modulo n", Symbol: "+"})
+	RegisterOp(PosRatSet{}.Mul, Operation{Name: "multiplication", Symbol: "·"})
+}
@@ -0,0 +1,63 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestLookupOp(t *testing.T) {
+	s := NewIntTuple(2)
+	o, ok := LookupOp(s.Add)
+	if !ok {
+		t.Fatalf("expected %s Add to be registered", s.Name())
+	}
+	if want, got := "+", o.Symbol; want != got {
+		t.Errorf("expected symbol %s but got %s", want, got)
+	}
+	// Method values of other receivers share the registration.
+	if _, ok := LookupOp(NewIntTuple(5).Add); !ok {
+		t.Errorf("expected ℤ^5 Add to be registered")
+	}
+	if _, ok := LookupOp(s.Sub); ok {
+		t.Errorf("expected %s Sub not to be registered", s.Name())
+	}
+}
+
+func maxOp(x, y Elem) Elem {
+	if x.Compare(y) < 0 {
+		return y
+	}
+	return x
+}
+
+func TestRegisterOp(t *testing.T) {
+	if want, got := "maxOp", DescribeOp(maxOp).Symbol; want != got {
+		t.Errorf("expected unregistered symbol %s but got %s", want, got)
+	}
+	if want, got := "ModSet.Sub", DescribeOp(NewMod(3).Sub).Symbol; want != got {
+		t.Errorf("expected unregistered method symbol %s but got %s", want, got)
+	}
+	RegisterOp(maxOp, Operation{Name: "maximum", Symbol: "∨", Identity: "⊥"})
+	t.Cleanup(func() {
+		ops.Lock()
+		defer ops.Unlock()
+		delete(ops.m, opName(maxOp))
+	})
+	if want, got := (Operation{Name: "maximum", Symbol: "∨", Identity: "⊥"}), DescribeOp(maxOp); want != got {
+		t.Errorf("expected registered op %v but got %v", want, got)
+	}
+}
+
+func TestLookupOpClosures(t *testing.T) {
+	constant := func(k int) BinOp {
+		return func(x, y Elem) Elem { return ModInt(k) }
+	}
+	if _, ok := LookupOp(constant(1)); ok {
+		t.Errorf("expected closure not to be registered")
+	}
+	if !closureName.MatchString(opName(constant(1))) || closureName.MatchString(opName(maxOp)) {
+		t.Errorf("expected only %s to be a closure", opName(constant(1)))
+	}
+	if want, got := opName(NewMod(2).Add), opName(NewMod(3).Add); want != got {
+		t.Errorf("expected method values to share the name %s but got %s", want, got)
+	}
+}
@@ -0,0 +1,123 @@
+package set
+
+import (
+	"fmt"
+	"log"
+)
+
+// IntTupleParallelepiped is the image f(r) of an IntTupleInterval r
+// under an Automorphism f, which is a finite subset of IntTuple that
+// can be enumerated. Unlike an interval, it is not a box in general,
+// e.g. a skew maps a square to a parallelogram.
+type IntTupleParallelepiped struct {
+	Set
+	box IntTupleInterval
+	f   Automorphism
+}
+
+// Box returns the interval r of f(r).
+func (p IntTupleParallelepiped) Box() IntTupleInterval {
+	return p.box
+}
+
+// Automorphism returns the automorphism f of f(r).
+func (p IntTupleParallelepiped) Automorphism() Automorphism {
+	return p.f
+}
+
+// IsIn returns true if x ∈ p, i.e. f⁻¹(x) is in the box of r.
+func (p IntTupleParallelepiped) IsIn(x Elem) bool {
+	xElem, ok := x.(IntTuple)
+	if !ok || xElem.Size() != p.f.Size() {
+		return false
+	}
+	y := p.f.inv.Apply(xElem)
+	for i := range y {
+		if y[i] < p.box.lo[i] || y[i] > p.box.hi[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p IntTupleParallelepiped) superset() Set {
+	return p.Set
+}
+
+// Name returns the description of the subset.
+func (p IntTupleParallelepiped) Name() string {
+	return fmt.Sprintf("%s·(%s)", p.f.a, p.box.Name())
+}
+
+// Bounds returns the smallest interval containing the parallelepiped.
+//
+// Bounds is only defined for a non-empty parallelepiped.
+func (p IntTupleParallelepiped) Bounds() IntTupleInterval {
+	if p.Cardinality() == 0 {
+		log.Fatalf("cannot bound %s: parallelepiped is empty", p.Name())
+	}
+	n := p.f.Size()
+	lo, hi := make(IntTuple, n), make(IntTuple, n)
+	for i := 0; i < n; i++ {
+		// Each coordinate is linear, so it is extreme at the corners.
+		for j := 0; j < n; j++ {
+			a := p.f.a.a[i*n+j]
+			if a >= 0 {
+				lo[i], hi[i] = lo[i]+a*p.box.lo[j], hi[i]+a*p.box.hi[j]
+			} else {
+				lo[i], hi[i] = lo[i]+a*p.box.hi[j], hi[i]+a*p.box.lo[j]
+			}
+		}
+	}
+	return IntTupleInterval{Set: p.Set, lo: lo, hi: hi}
+}
+
+// Cardinality returns the number of IntTuples in the parallelepiped,
+// the same as the interval since f is a bijection.
+func (p IntTupleParallelepiped) Cardinality() int {
+	return p.box.Cardinality()
+}
+
+// Enumerate creates an iterator for looping over the IntTuple in the
+// parallelepiped, in the lexicographical order of their preimages in the
+// interval, without visiting the points of the bounding interval which
+// lie outside the parallelepiped.
+//
+// For an empty parallelepiped, Next returns a nil Elem.
+func (p IntTupleParallelepiped) Enumerate() Nexter {
+	if p.Cardinality() == 0 {
+		return &FiniteSetIter{}
+	}
+	return &IntTupleParallelepipedIter{f: p.f, box: p.box.Enumerate()}
+}
+
+// Slice returns the Elem in the parallelepiped as a slice,
+// in the same order as Enumerate.
+func (p IntTupleParallelepiped) Slice() []Elem {
+	s := make([]Elem, 0, p.Cardinality())
+	if p.Cardinality() == 0 {
+		return s
+	}
+	e := p.Enumerate()
+	for {
+		next, more := e.Next()
+		s = append(s, next)
+		if !more {
+			break
+		}
+	}
+	return s
+}
+
+// IntTupleParallelepipedIter is a IntTupleParallelepiped iterator.
+type IntTupleParallelepipedIter struct {
+	f   Automorphism
+	box Nexter
+}
+
+// Next returns the next Elem in the parallelepiped, and indicates
+// if there are more elements in the parallelepiped with more.
+func (n *IntTupleParallelepipedIter) Next() (next Elem, more bool) {
+	x, more := n.box.Next()
+	return n.f.Apply(x.(IntTuple)), more
+}
@@ -0,0 +1,53 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParallelepiped(t *testing.T) {
+	s := NewIntTuple(2)
+	r := s.Interval(IntTuple{0, 0}, IntTuple{1, 2}).(IntTupleInterval)
+	p := Skew(2, 0, 1, 1).Image(r) // (x,y) ↦ (x+y,y)
+	if want, got := 6, p.Cardinality(); want != got {
+		t.Errorf("expected cardinality %d but got %d", want, got)
+	}
+	elems := p.Slice()
+	if want, got := "[(0,0) (1,1) (2,2) (1,0) (2,1) (3,2)]", fmt.Sprint(elems); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	for _, x := range elems {
+		if !p.IsIn(x) {
+			t.Errorf("expected %v ∈ %s", x, p.Name())
+		}
+	}
+	if p.IsIn(IntTuple{0, 1}) || p.IsIn(IntTuple{3, 0}) {
+		t.Errorf("expected points of the bounding box outside %s", p.Name())
+	}
+	if want, got := "(0,0)≤..≤(3,2)", p.Bounds().Name(); want != got {
+		t.Errorf("expected bounds %s but got %s", want, got)
+	}
+	e := Rotation2D(1).Image(s.Interval(IntTuple{0, 0}, IntTuple{1, 0}).(IntTupleInterval)).Enumerate()
+	var got []Elem
+	for {
+		next, more := e.Next()
+		got = append(got, next)
+		if !more {
+			break
+		}
+	}
+	if want := "[(0,0) (0,1)]"; want != fmt.Sprint(got) {
+		t.Errorf("expected %s but got %s", want, fmt.Sprint(got))
+	}
+}
+
+func TestParallelepipedEmpty(t *testing.T) {
+	s := NewIntTuple(2)
+	p := Reflection(2, 0).Image(s.Interval(IntTuple{1, 1}, IntTuple{0, 0}).(IntTupleInterval))
+	if want, got := 0, len(p.Slice()); want != got {
+		t.Errorf("expected %d elements but got %d", want, got)
+	}
+	if next, more := p.Enumerate().Next(); next != nil || more {
+		t.Errorf("expected empty iterator but got %v, %t", next, more)
+	}
+}
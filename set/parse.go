@@ -0,0 +1,222 @@
+package set
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is the type of error where a text cannot be
+// parsed into an Elem or a Set.
+type ParseError struct {
+	Text string // Text is the input being parsed.
+	Pos  int    // Pos is the byte offset of the error in Text.
+	Msg  string
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("cannot parse %q at position %d: %s", e.Text, e.Pos, e.Msg)
+}
+
+// ElemParser is implemented by sets which can parse
+// the String representation of their Elems.
+type ElemParser interface {
+	ParseElem(text string) (Elem, error)
+}
+
+// ParseElem parses text into an Elem of the set s.
+//
+// The set s (or the set it is a subset of) must implement ElemParser,
+// and the parsed Elem must be a member of s.
+func ParseElem(s Set, text string) (Elem, error) {
+	p, ok := s.(ElemParser)
+	if !ok {
+		if sub, isSubset := s.(interface{ superset() Set }); isSubset {
+			p, ok = sub.superset().(ElemParser)
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("cannot parse %q: set %s does not implement ElemParser", text, s.Name())
+	}
+	x, err := p.ParseElem(text)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsIn(x) {
+		return nil, fmt.Errorf("cannot parse %q: %s is not a member of %s", text, x, s.Name())
+	}
+	return x, nil
+}
+
+// ParseElem parses text into an IntTuple of s.
+// It accepts the output of IntTuple.String.
+func (s IntTupleSet) ParseElem(text string) (Elem, error) {
+	x, err := ParseIntTuple(text)
+	if err != nil {
+		return nil, err
+	}
+	if x.Size() != s.Size() {
+		return nil, fmt.Errorf("cannot parse %q as member of %s: %v", text, s.Name(), MismatchDimErr{Dim1: x.Size(), Dim2: s.Size()})
+	}
+	return x, nil
+}
+
+// ParseIntTuple parses text into an IntTuple.
+//
+// It accepts the output of IntTuple.String, i.e. an integer "3"
+// or a parenthesised tuple "(1,2)", with optional spaces, tabs or
+// newlines between the tokens, including after a sign, e.g. "(- 3, +2)",
+// which is the grammar of tuple literals in package expr.
+// A parenthesised single integer "(3)" is the same as "3".
+func ParseIntTuple(text string) (IntTuple, error) {
+	p := &tupleParser{text: text}
+	p.skipSpace()
+	var x IntTuple
+	if p.peek() == '(' {
+		p.pos++
+		p.skipSpace()
+		if p.peek() == ')' {
+			p.pos++
+			x = IntTuple{}
+		} else {
+			for {
+				v, err := p.integer()
+				if err != nil {
+					return nil, err
+				}
+				x = append(x, v)
+				p.skipSpace()
+				if c := p.peek(); c == ')' {
+					p.pos++
+					break
+				} else if c != ',' {
+					return nil, p.errorf("expecting ',' or ')' but got %s", p.describe())
+				}
+				p.pos++
+			}
+		}
+	} else {
+		v, err := p.integer()
+		if err != nil {
+			return nil, err
+		}
+		x = IntTuple{v}
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return nil, p.errorf("unexpected %s after tuple", p.describe())
+	}
+	return x, nil
+}
+
+// tupleParser is a cursor over the text of an IntTuple.
+type tupleParser struct {
+	text string
+	pos  int
+}
+
+func (p *tupleParser) errorf(format string, args ...interface{}) error {
+	return ParseError{Text: p.text, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the next byte or 0 at the end of text.
+func (p *tupleParser) peek() byte {
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+// describe returns a description of the next rune for error messages.
+func (p *tupleParser) describe() string {
+	if p.pos >= len(p.text) {
+		return "end of input"
+	}
+	r, _ := utf8.DecodeRuneInString(p.text[p.pos:])
+	return strconv.QuoteRune(r)
+}
+
+func (p *tupleParser) skipSpace() {
+	for p.pos < len(p.text) && (p.text[p.pos] == ' ' || p.text[p.pos] == '\t' || p.text[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// integer parses an optionally signed decimal integer,
+// which may have spaces between the sign and the digits.
+func (p *tupleParser) integer() (int, error) {
+	p.skipSpace()
+	start, sign := p.pos, ""
+	if c := p.peek(); c == '-' || c == '+' {
+		sign = string(c)
+		p.pos++
+		p.skipSpace()
+	}
+	digits := p.pos
+	for p.pos < len(p.text) && isDigit(p.text[p.pos]) {
+		p.pos++
+	}
+	if p.pos == digits {
+		return 0, p.errorf("expecting integer but got %s", p.describe())
+	}
+	v, err := strconv.Atoi(sign + p.text[digits:p.pos])
+	if err != nil {
+		num := p.text[start:p.pos]
+		p.pos = start
+		return 0, p.errorf("invalid integer %q: %v", num, err.(*strconv.NumError).Err)
+	}
+	return v, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// ParseSet parses the name of a set.
+//
+// It accepts the output of IntTupleSet.Name, e.g. "ℤ" or "ℤxℤxℤ"
+// (× is also accepted as the separator), and the ASCII forms
+// "Z", "ZxZxZ" or "Z^3". "∅", "Z^0" and "ℤ^0" are the 0-tuple set.
+func ParseSet(text string) (Set, error) {
+	t := strings.TrimSpace(text)
+	if t == "∅" {
+		return NewIntTuple(0), nil
+	}
+	// Power form: ℤ^n or Z^n.
+	if i := strings.Index(t, "^"); i >= 0 {
+		if !isInt(strings.TrimSpace(t[:i])) {
+			return nil, ParseError{Text: text, Pos: 0, Msg: fmt.Sprintf("unknown set %q, expecting ℤ or Z", strings.TrimSpace(t[:i]))}
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(t[i+1:]))
+		if err != nil || n < 0 {
+			return nil, ParseError{Text: text, Pos: strings.Index(text, "^") + 1, Msg: fmt.Sprintf("invalid exponent %q, expecting non-negative integer", strings.TrimSpace(t[i+1:]))}
+		}
+		return NewIntTuple(n), nil
+	}
+	// Product form: ℤxℤx...
+	size, pos := 0, strings.Index(text, t)
+	for {
+		end := strings.IndexAny(t, "x×")
+		f := t
+		if end >= 0 {
+			f = t[:end]
+		}
+		if !isInt(strings.TrimSpace(f)) {
+			return nil, ParseError{Text: text, Pos: pos, Msg: fmt.Sprintf("unknown set %q, expecting ℤ or Z", strings.TrimSpace(f))}
+		}
+		size++
+		if end < 0 {
+			break
+		}
+		_, sep := utf8.DecodeRuneInString(t[end:])
+		pos += end + sep
+		t = t[end+sep:]
+	}
+	return NewIntTuple(size), nil
+}
+
+// isInt returns true if name is a name of the integers.
+func isInt(name string) bool {
+	return name == "ℤ" || name == "Z"
+}
@@ -0,0 +1,140 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestParseIntTuple(t *testing.T) {
+	tests := []struct {
+		text string
+		want IntTuple
+	}{
+		{"3", IntTuple{3}},
+		{"-3", IntTuple{-3}},
+		{"+3", IntTuple{3}},
+		{"(3)", IntTuple{3}},
+		{"()", IntTuple{}},
+		{"(1,2)", IntTuple{1, 2}},
+		{" ( 1 , -2 ,3 ) ", IntTuple{1, -2, 3}},
+		{"(- 3, +2)", IntTuple{-3, 2}},
+		{"(+ 1,\n-\t2)", IntTuple{1, -2}},
+	}
+	for _, tt := range tests {
+		got, err := ParseIntTuple(tt.text)
+		if err != nil {
+			t.Errorf("ParseIntTuple(%q): unexpected error: %v", tt.text, err)
+			continue
+		}
+		if tt.want.Size() != got.Size() || tt.want.Compare(got) != 0 {
+			t.Errorf("ParseIntTuple(%q): expected %s but got %s", tt.text, tt.want, got)
+		}
+	}
+}
+
+func TestParseIntTupleRoundTrip(t *testing.T) {
+	for _, x := range []IntTuple{{}, {0}, {-7}, {1, 2}, {-1, 0, 42}} {
+		got, err := ParseIntTuple(x.String())
+		if err != nil {
+			t.Errorf("ParseIntTuple(%q): unexpected error: %v", x.String(), err)
+			continue
+		}
+		if x.Size() != got.Size() || x.Compare(got) != 0 {
+			t.Errorf("expected %s but got %s", x, got)
+		}
+	}
+}
+
+func TestParseIntTupleError(t *testing.T) {
+	tests := []struct {
+		text string
+		pos  int
+	}{
+		{"", 0},
+		{"(1,", 3},
+		{"(1,x)", 3},
+		{"(1 2)", 3},
+		{"(1,2))", 5},
+		{"-", 1},
+		{"(- x)", 3},
+		{"99999999999999999999", 0},
+	}
+	for _, tt := range tests {
+		_, err := ParseIntTuple(tt.text)
+		perr, ok := err.(ParseError)
+		if !ok {
+			t.Errorf("ParseIntTuple(%q): expected ParseError but got %v", tt.text, err)
+			continue
+		}
+		if tt.pos != perr.Pos {
+			t.Errorf("ParseIntTuple(%q): expected error at %d but got %d (%v)", tt.text, tt.pos, perr.Pos, perr)
+		}
+	}
+}
+
+func TestParseElem(t *testing.T) {
+	s := NewIntTuple(2)
+	x, err := ParseElem(s, "(1,2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := s.Tuple(1, 2); want.Compare(x) != 0 {
+		t.Errorf("expected %s but got %s", want, x)
+	}
+	if _, err := ParseElem(s, "(1,2,3)"); err == nil {
+		t.Errorf("expected dimension mismatch error")
+	}
+
+	iv := s.Interval(s.Tuple(0, 0), s.Tuple(2, 2))
+	if _, err := ParseElem(iv.(Set), "(1,1)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseElem(iv.(Set), "(3,1)"); err == nil {
+		t.Errorf("expected (3,1) not to be a member of %s", iv.(Set).Name())
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"ℤ", 1},
+		{"ℤxℤ", 2},
+		{"ℤ×ℤ×ℤ", 3},
+		{"Z", 1},
+		{"ZxZ", 2},
+		{"Z^3", 3},
+		{"ℤ^4", 4},
+		{" Z ^ 2 ", 2},
+		{"∅", 0},
+		{"Z^0", 0},
+	}
+	for _, tt := range tests {
+		s, err := ParseSet(tt.text)
+		if err != nil {
+			t.Errorf("ParseSet(%q): unexpected error: %v", tt.text, err)
+			continue
+		}
+		if got := s.(IntTupleSet).Size(); tt.want != got {
+			t.Errorf("ParseSet(%q): expected size %d but got %d", tt.text, tt.want, got)
+		}
+	}
+	for size := 0; size < 4; size++ {
+		s, err := ParseSet(NewIntTuple(size).Name())
+		if err != nil {
+			t.Errorf("ParseSet(%q): unexpected error: %v", NewIntTuple(size).Name(), err)
+			continue
+		}
+		if got := s.(IntTupleSet).Size(); size != got {
+			t.Errorf("expected size %d but got %d", size, got)
+		}
+	}
+}
+
+func TestParseSetError(t *testing.T) {
+	for _, text := range []string{"", "ℚ", "ZxQ", "Zx", "Z^-1", "Z^x", "R^2"} {
+		if _, err := ParseSet(text); err == nil {
+			t.Errorf("ParseSet(%q): expected error", text)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package set
+
+import (
+	"encoding/binary"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PolySet is the set of integer polynomials in n variables, ℤ[x] or
+// ℤ[x1,...,xn], which forms a torsion-free abelian group under Add.
+//
+// The exponents of a monomial, e.g. (2,0,1) for x1^2·x3, are an IntTuple
+// of the set Exponents. Polynomials are ordered by the graded
+// lexicographic order of monomials: x < y if the leading coefficient
+// of y - x is positive, which is compatible with Add.
+type PolySet int
+
+// NewPoly returns the set of integer polynomials in n > 0 variables.
+func NewPoly(n int) PolySet {
+	if n <= 0 {
+		log.Fatalf("cannot create polynomials in %d variables", n)
+	}
+	return PolySet(n)
+}
+
+// Vars returns the number of variables of the set.
+func (s PolySet) Vars() int {
+	return int(s)
+}
+
+// Exponents returns the set of exponents of the monomials, i.e. ℤ^n.
+func (s PolySet) Exponents() IntTupleSet {
+	return NewIntTuple(s.Vars())
+}
+
+// Monomial is a term Coef·x^Exp of a Poly, e.g. 3·x1^2·x3
+// for Coef 3 and Exp (2,0,1).
+type Monomial struct {
+	Coef int
+	Exp  IntTuple
+}
+
+// Degree returns the total degree of the monomial.
+func (m Monomial) Degree() int {
+	deg := 0
+	for _, e := range m.Exp {
+		deg += e
+	}
+	return deg
+}
+
+// Poly returns the polynomial with the terms,
+// combining terms with the same exponents.
+//
+// The exponents must be non-negative and have n coordinates,
+// otherwise it throws a runtime error.
+func (s PolySet) Poly(terms ...Monomial) Poly {
+	coefs := make(map[string]int, len(terms))
+	exps := make(map[string]IntTuple, len(terms))
+	for _, t := range terms {
+		if t.Exp.Size() != s.Vars() {
+			log.Fatal(MismatchDimErr{Dim1: t.Exp.Size(), Dim2: s.Vars()})
+		}
+		for _, e := range t.Exp {
+			if e < 0 {
+				log.Fatalf("cannot create polynomial in %s: negative exponent %v", s.Name(), t.Exp)
+			}
+		}
+		k := t.Exp.Key()
+		coefs[k] += t.Coef
+		exps[k] = t.Exp
+	}
+	p := make(Poly, 0, len(coefs))
+	for k, c := range coefs {
+		if c != 0 {
+			p = append(p, Monomial{Coef: c, Exp: exps[k].Clone()})
+		}
+	}
+	sort.Slice(p, func(i, j int) bool { return compareMonomials(p[i].Exp, p[j].Exp) > 0 })
+	return p
+}
+
+// Const returns the constant polynomial c.
+func (s PolySet) Const(c int) Poly {
+	return s.Poly(Monomial{Coef: c, Exp: make(IntTuple, s.Vars())})
+}
+
+// X returns the polynomial xi, the i-th variable for 0 ≤ i < n.
+func (s PolySet) X(i int) Poly {
+	if i < 0 || i >= s.Vars() {
+		log.Fatalf("variable %d out of range of %s", i, s.Name())
+	}
+	exp := make(IntTuple, s.Vars())
+	exp[i] = 1
+	return s.Poly(Monomial{Coef: 1, Exp: exp})
+}
+
+// Identity returns the zero polynomial, the identity of the set.
+func (s PolySet) Identity() Elem {
+	return Poly{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s PolySet) IsIn(x Elem) bool {
+	xElem, ok := x.(Poly)
+	if !ok {
+		return false
+	}
+	for _, t := range xElem {
+		if t.Exp.Size() != s.Vars() {
+			return false
+		}
+		for _, e := range t.Exp {
+			if e < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Name returns the formal name of the set, e.g. ℤ[x] or ℤ[x1,x2,x3].
+func (s PolySet) Name() string {
+	if s.Vars() == 1 {
+		return "ℤ[x]"
+	}
+	vars := make([]string, s.Vars())
+	for i := range vars {
+		vars[i] = "x" + strconv.Itoa(i+1)
+	}
+	return "ℤ[" + strings.Join(vars, ",") + "]"
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s PolySet) Add(x, y Elem) Elem {
+	return x.(Poly).add(y.(Poly), 1)
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s PolySet) Sub(x, y Elem) Elem {
+	return x.(Poly).add(y.(Poly), -1)
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s PolySet) Inverse(x Elem) Elem {
+	return Poly{}.add(x.(Poly), -1)
+}
+
+// Mul returns the product x·y, e.g. for multiplying generating functions.
+func (s PolySet) Mul(x, y Elem) Elem {
+	xElem, yElem := x.(Poly), y.(Poly)
+	terms := make([]Monomial, 0, len(xElem)*len(yElem))
+	for _, t := range xElem {
+		for _, u := range yElem {
+			terms = append(terms, Monomial{Coef: t.Coef * u.Coef, Exp: s.Exponents().Add(t.Exp, u.Exp).(IntTuple)})
+		}
+	}
+	return s.Poly(terms...)
+}
+
+// Less returns x < y.
+func (s PolySet) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s PolySet) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s PolySet) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// compareMonomials compares exponents x and y in graded lexicographic
+// order, i.e. by total degree, then lexicographically.
+func compareMonomials(x, y IntTuple) int {
+	if c := compareInts(Monomial{Exp: x}.Degree(), Monomial{Exp: y}.Degree()); c != 0 {
+		return c
+	}
+	return x.Compare(y)
+}
+
+// Poly is an Elem of a PolySet, stored sparsely as its nonzero terms
+// in descending graded lexicographic order of exponents.
+//
+// The zero value is the zero polynomial.
+type Poly []Monomial
+
+// add returns x + sign·y.
+func (x Poly) add(y Poly, sign int) Poly {
+	z := make(Poly, 0, len(x)+len(y))
+	j, k := 0, 0
+	for j < len(x) || k < len(y) {
+		c := 1 // compares the next terms of x and y.
+		if j == len(x) {
+			c = -1
+		} else if k < len(y) {
+			c = compareMonomials(x[j].Exp, y[k].Exp)
+		}
+		var t Monomial
+		switch {
+		case c > 0:
+			t = x[j]
+			j++
+		case c < 0:
+			t = Monomial{Coef: sign * y[k].Coef, Exp: y[k].Exp}
+			k++
+		default:
+			t = Monomial{Coef: x[j].Coef + sign*y[k].Coef, Exp: x[j].Exp}
+			j++
+			k++
+		}
+		if t.Coef != 0 {
+			z = append(z, t)
+		}
+	}
+	return z
+}
+
+// Terms returns the nonzero terms of x in descending order.
+func (x Poly) Terms() []Monomial {
+	terms := make([]Monomial, len(x))
+	for i, t := range x {
+		terms[i] = Monomial{Coef: t.Coef, Exp: t.Exp.Clone()}
+	}
+	return terms
+}
+
+// Coef returns the coefficient of x at the monomial with the exponents
+// exp, which must have an exponent for each variable of s.
+//
+// Unlike Poly.Coef, it checks the number of exponents
+// even if x is the zero polynomial.
+func (s PolySet) Coef(x Elem, exp ...int) int {
+	if len(exp) != s.Vars() {
+		log.Fatal(MismatchDimErr{Dim1: len(exp), Dim2: s.Vars()})
+	}
+	return x.(Poly).Coef(exp...)
+}
+
+// Coef returns the coefficient of the monomial with the exponents exp,
+// which has an exponent for each variable of x.
+//
+// The zero polynomial has no terms to check the number of exponents
+// against, so its coefficients are 0 for any exp; use PolySet.Coef
+// to check exp against the variables of the set.
+func (x Poly) Coef(exp ...int) int {
+	for _, t := range x {
+		if t.Exp.Size() != len(exp) {
+			log.Fatal(MismatchDimErr{Dim1: len(exp), Dim2: t.Exp.Size()})
+		}
+		if t.Exp.Compare(IntTuple(exp)) == 0 {
+			return t.Coef
+		}
+	}
+	return 0
+}
+
+// Degree returns the total degree of x, or -1 for the zero polynomial.
+func (x Poly) Degree() int {
+	if len(x) == 0 {
+		return -1
+	}
+	return x[0].Degree()
+}
+
+// Leading returns the leading term of x in graded lexicographic order,
+// or the zero Monomial for the zero polynomial.
+func (x Poly) Leading() Monomial {
+	if len(x) == 0 {
+		return Monomial{}
+	}
+	return Monomial{Coef: x[0].Coef, Exp: x[0].Exp.Clone()}
+}
+
+// Eval returns the value of x at the point v, which
+// has a coordinate for each variable of x.
+func (x Poly) Eval(v ...int) int {
+	sum := 0
+	for _, t := range x {
+		if t.Exp.Size() != len(v) {
+			log.Fatal(MismatchDimErr{Dim1: len(v), Dim2: t.Exp.Size()})
+		}
+		term := t.Coef
+		for i, e := range t.Exp {
+			for ; e > 0; e-- {
+				term *= v[i]
+			}
+		}
+		sum += term
+	}
+	return sum
+}
+
+// String returns the polynomial as a sum of terms, e.g. 3·x^2 - x + 1
+// or x1^2·x3 - 2·x2. The zero polynomial is 0.
+func (x Poly) String() string {
+	if len(x) == 0 {
+		return "0"
+	}
+	var buf strings.Builder
+	for i, t := range x {
+		c := t.Coef
+		switch {
+		case i == 0 && c < 0:
+			buf.WriteRune('-')
+			c = -c
+		case i > 0 && c < 0:
+			buf.WriteString(" - ")
+			c = -c
+		case i > 0:
+			buf.WriteString(" + ")
+		}
+		vars := t.vars()
+		switch {
+		case vars == "":
+			buf.WriteString(strconv.Itoa(c))
+		case c == 1:
+			buf.WriteString(vars)
+		default:
+			buf.WriteString(strconv.Itoa(c) + "·" + vars)
+		}
+	}
+	return buf.String()
+}
+
+// vars returns the product of the variables of the monomial, e.g. x1^2·x3.
+func (m Monomial) vars() string {
+	var vars []string
+	for i, e := range m.Exp {
+		if e == 0 {
+			continue
+		}
+		v := "x"
+		if m.Exp.Size() > 1 {
+			v += strconv.Itoa(i + 1)
+		}
+		if e > 1 {
+			v += "^" + strconv.Itoa(e)
+		}
+		vars = append(vars, v)
+	}
+	return strings.Join(vars, "·")
+}
+
+// Compare returns 0 if x == y, -ve int if x < y, +ve int if x > y,
+// i.e. the sign of the leading coefficient of x - y.
+func (x Poly) Compare(y Elem) int {
+	d := x.add(y.(Poly), -1)
+	if len(d) == 0 {
+		return 0
+	}
+	return compareInts(d[0].Coef, 0)
+}
+
+// Key returns the binary encoding of the terms of x as a string.
+func (x Poly) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	var buf []byte
+	for _, t := range x {
+		buf = append(buf, tmp[:binary.PutVarint(tmp[:], int64(t.Coef))]...)
+		buf = append(buf, t.Exp.Key()...)
+	}
+	return string(buf)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of x.
+func (x Poly) Hash() uint64 {
+	return hashKey(x.Key())
+}
@@ -0,0 +1,94 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestPoly(t *testing.T) {
+	s := NewPoly(1)
+	if want, got := "ℤ[x]", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	x := s.X(0)
+	p := s.Add(s.Mul(s.Const(3), s.Mul(x, x)), s.Sub(s.Const(1), x)).(Poly) // 3x² - x + 1
+	if want, got := "3·x^2 - x + 1", p.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := 2, p.Degree(); want != got {
+		t.Errorf("expected degree %d but got %d", want, got)
+	}
+	if want, got := 11, p.Eval(2); want != got {
+		t.Errorf("expected p(2) = %d but got %d", want, got)
+	}
+	if want, got := -1, p.Coef(1); want != got {
+		t.Errorf("expected coefficient of x to be %d but got %d", want, got)
+	}
+	if want, got := s.Identity(), s.Add(p, s.Inverse(p)); want.Compare(got) != 0 || Key(want) != Key(got) {
+		t.Errorf("expected p + -p = %v but got %v", want, got)
+	}
+	if want, got := -1, s.Identity().(Poly).Degree(); want != got {
+		t.Errorf("expected degree of 0 to be %d but got %d", want, got)
+	}
+}
+
+func TestPolyMultivariate(t *testing.T) {
+	s := NewPoly(3)
+	if want, got := "ℤ[x1,x2,x3]", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	p := s.Poly(
+		Monomial{Coef: -2, Exp: IntTuple{0, 1, 0}},
+		Monomial{Coef: 1, Exp: IntTuple{2, 0, 1}},
+		Monomial{Coef: 4, Exp: IntTuple{0, 1, 0}},
+		Monomial{Coef: -5, Exp: IntTuple{0, 0, 0}},
+	)
+	if want, got := "x1^2·x3 + 2·x2 - 5", p.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "(2,0,1)", p.Leading().Exp.String(); want != got {
+		t.Errorf("expected leading exponents %s but got %s", want, got)
+	}
+	if !s.Exponents().IsIn(p.Leading().Exp) {
+		t.Errorf("expected exponents to be in %s", s.Exponents().Name())
+	}
+	if want, got := 3, p.Eval(1, 2, 4); want != got {
+		t.Errorf("expected p(1,2,4) = %d but got %d", want, got)
+	}
+	if want, got := 2, p.Coef(0, 1, 0); want != got {
+		t.Errorf("expected coefficient of x2 to be %d but got %d", want, got)
+	}
+	if want, got := 0, p.Coef(1, 0, 0); want != got {
+		t.Errorf("expected coefficient of x1 to be %d but got %d", want, got)
+	}
+	if want, got := 2, s.Coef(p, 0, 1, 0); want != got {
+		t.Errorf("expected coefficient of x2 to be %d but got %d", want, got)
+	}
+	if want, got := 0, s.Coef(s.Identity(), 0, 1, 0); want != got {
+		t.Errorf("expected coefficient of x2 in 0 to be %d but got %d", want, got)
+	}
+	if s.IsIn(Poly{Monomial{Coef: 1, Exp: IntTuple{0, -1, 0}}}) {
+		t.Errorf("expected polynomial with negative exponent not to be in %s", s.Name())
+	}
+	if s.IsIn(Poly{Monomial{Coef: 1, Exp: IntTuple{0, 1}}}) {
+		t.Errorf("expected polynomial in 2 variables not to be in %s", s.Name())
+	}
+}
+
+func TestPolyOrder(t *testing.T) {
+	s := NewPoly(2)
+	x1, x2 := s.X(0), s.X(1)
+	// Graded: x2 < x1 < x2^2 < x1·x2 < x1^2.
+	ps := []Poly{x2, x1, s.Mul(x2, x2).(Poly), s.Mul(x1, x2).(Poly), s.Mul(x1, x1).(Poly)}
+	for i := 1; i < len(ps); i++ {
+		if !s.Less(ps[i-1], ps[i]) || s.Less(ps[i], ps[i-1]) {
+			t.Errorf("expected %v < %v", ps[i-1], ps[i])
+		}
+	}
+	// Compatible with Add: x1 - 100 < x1 < x1 + x2.
+	if !s.Less(s.Sub(x1, s.Const(100)), x1) || !s.Less(x1, s.Add(x1, x2)) {
+		t.Errorf("expected order to be compatible with Add")
+	}
+	if !s.Less(s.Inverse(x1), s.Identity()) {
+		t.Errorf("expected -x1 < 0")
+	}
+}
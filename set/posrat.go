@@ -0,0 +1,360 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PosRatSet is the set of positive rationals ℚ>0, which forms
+// a torsion-free abelian group under Mul.
+//
+// By unique factorisation, each x ∈ ℚ>0 is 2^a·3^b·5^c... for integer
+// exponents a, b, c..., so ℚ>0 is the free abelian group over the primes,
+// and x·y adds the exponents. The Elems are stored as their exponent
+// vectors, which can be mapped to and from SparseIntTuples with ToSparse
+// and FromSparse if the set is restricted to the first n primes.
+type PosRatSet struct {
+	primes []int // the first n primes, nil if any prime is allowed.
+}
+
+// NewPosRat returns the subgroup of ℚ>0 generated by the first n primes,
+// which is isomorphic to ℤ^n, or ℚ>0 if n = 0.
+func NewPosRat(n int) PosRatSet {
+	if n < 0 {
+		log.Fatalf("cannot create subgroup of ℚ>0 with %d primes", n)
+	}
+	if n == 0 {
+		return PosRatSet{}
+	}
+	return PosRatSet{primes: firstPrimes(n)}
+}
+
+// firstPrimes returns the first n primes.
+func firstPrimes(n int) []int {
+	ps := make([]int, 0, n)
+	for c := 2; len(ps) < n; c++ {
+		prime := true
+		for _, p := range ps {
+			if p*p > c {
+				break
+			}
+			if c%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			ps = append(ps, c)
+		}
+	}
+	return ps
+}
+
+// Primes returns the primes which generate the set,
+// or nil if the set is ℚ>0.
+func (s PosRatSet) Primes() []int {
+	return append([]int(nil), s.primes...)
+}
+
+// isGenerator returns true if the prime p is a generator of the set.
+func (s PosRatSet) isGenerator(p int) bool {
+	return s.primes == nil || p <= s.primes[len(s.primes)-1]
+}
+
+// Rat returns the rational num/den, where num and den are positive.
+//
+// The prime factors of num/den in lowest terms must be generators
+// of the set, otherwise it throws a runtime error.
+func (s PosRatSet) Rat(num, den int) PosRat {
+	if num <= 0 || den <= 0 {
+		log.Fatalf("cannot create %d/%d in %s: not positive", num, den, s.Name())
+	}
+	x, p := s.rat(num, den)
+	if p != 0 {
+		log.Fatalf("cannot create %d/%d in %s: %d is not a generator", num, den, s.Name(), p)
+	}
+	return x
+}
+
+// rat returns the positive rational num/den, or a prime
+// factor p of num/den which is not a generator of s.
+func (s PosRatSet) rat(num, den int) (x PosRat, p int) {
+	exps := make(map[int]int)
+	factor(num, exps, 1)
+	factor(den, exps, -1)
+	for q, e := range exps {
+		if e != 0 {
+			x.p = append(x.p, q)
+		}
+	}
+	sort.Ints(x.p)
+	x.e = make([]int, len(x.p))
+	for i, q := range x.p {
+		if !s.isGenerator(q) {
+			return PosRat{}, q
+		}
+		x.e[i] = exps[q]
+	}
+	return x, 0
+}
+
+// factor adds sign times the exponents of the prime factors of n to exps.
+func factor(n int, exps map[int]int, sign int) {
+	for n%2 == 0 && n > 0 {
+		exps[2] += sign
+		n /= 2
+	}
+	for p := 3; p <= n/p; p += 2 { // p*p <= n may overflow.
+		for n%p == 0 {
+			exps[p] += sign
+			n /= p
+		}
+	}
+	if n > 1 {
+		exps[n] += sign
+	}
+}
+
+// Identity returns 1, the identity of the set.
+func (s PosRatSet) Identity() Elem {
+	return PosRat{}
+}
+
+// IsIn returns true if x ∈ s.
+func (s PosRatSet) IsIn(x Elem) bool {
+	xElem, ok := x.(PosRat)
+	return ok && (len(xElem.p) == 0 || s.isGenerator(xElem.p[len(xElem.p)-1]))
+}
+
+// Name returns the formal name of the set, e.g. ℚ>0,
+// or ℚ>0⟨2,3,5⟩ if the set is generated by the first 3 primes.
+func (s PosRatSet) Name() string {
+	if s.primes == nil {
+		return "ℚ>0"
+	}
+	names := make([]string, len(s.primes))
+	for i, p := range s.primes {
+		names[i] = strconv.Itoa(p)
+	}
+	return "ℚ>0⟨" + strings.Join(names, ",") + "⟩"
+}
+
+// InfiniteRank is the Rank of a set of infinite torsion-free rank,
+// e.g. ℚ>0, which has no finite maximal independent subset.
+const InfiniteRank = -1
+
+// Rank returns the torsion-free rank of the set, i.e. the number of
+// primes which generate the set, or InfiniteRank if the set is ℚ>0.
+func (s PosRatSet) Rank() int {
+	if s.primes == nil {
+		return InfiniteRank
+	}
+	return len(s.primes)
+}
+
+// Mul is the · binary operation. It returns x·y.
+func (s PosRatSet) Mul(x, y Elem) Elem {
+	return x.(PosRat).merge(y.(PosRat), 1)
+}
+
+// Div is the / binary operation. It returns x/y.
+func (s PosRatSet) Div(x, y Elem) Elem {
+	return x.(PosRat).merge(y.(PosRat), -1)
+}
+
+// Inverse returns the multiplicative inverse of x, i.e. 1/x.
+func (s PosRatSet) Inverse(x Elem) Elem {
+	return PosRat{}.merge(x.(PosRat), -1)
+}
+
+// Less returns x < y, which is compatible with Mul
+// since multiplying by a positive rational preserves order.
+func (s PosRatSet) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s PosRatSet) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s PosRatSet) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// Sparse returns the set of exponent vectors of s, i.e. ℤ^n
+// for the subgroup generated by the first n primes.
+func (s PosRatSet) Sparse() SparseIntTupleSet {
+	if s.primes == nil {
+		log.Fatalf("cannot map %s to exponent vectors: rank is infinite, use NewPosRat(n)", s.Name())
+	}
+	return NewSparseIntTuple(len(s.primes))
+}
+
+// ToSparse returns the exponent vector of x, whose i-th coordinate is
+// the exponent of the i-th prime, e.g. (2,-1,0) for 4/3 in ℚ>0⟨2,3,5⟩.
+//
+// ToSparse is a group isomorphism from s to s.Sparse(),
+// i.e. ToSparse(x·y) = ToSparse(x) + ToSparse(y).
+func (s PosRatSet) ToSparse(x Elem) SparseIntTuple {
+	t := s.Sparse()
+	if !s.IsIn(x) {
+		log.Fatalf("cannot map %s to exponent vector: not a member of %s", x, s.Name())
+	}
+	xElem := x.(PosRat)
+	e := SparseIntTuple{size: t.Size(), idx: make([]int, len(xElem.p)), val: append([]int(nil), xElem.e...)}
+	for k, p := range xElem.p {
+		e.idx[k] = sort.SearchInts(s.primes, p)
+	}
+	return e
+}
+
+// FromSparse returns the rational with the exponent vector e,
+// the inverse of ToSparse.
+func (s PosRatSet) FromSparse(e SparseIntTuple) PosRat {
+	if e.Size() != s.Sparse().Size() {
+		log.Fatal(MismatchDimErr{Dim1: e.Size(), Dim2: s.Sparse().Size()})
+	}
+	x := PosRat{p: make([]int, len(e.idx)), e: append([]int(nil), e.val...)}
+	for k, i := range e.idx {
+		x.p[k] = s.primes[i]
+	}
+	return x
+}
+
+// ParseElem parses a positive rational, e.g. 3/4 or 6.
+// It accepts the output of PosRat.String.
+func (s PosRatSet) ParseElem(text string) (Elem, error) {
+	t := strings.TrimSpace(text)
+	numText, denText := t, "1"
+	if i := strings.IndexByte(t, '/'); i >= 0 {
+		numText, denText = strings.TrimSpace(t[:i]), strings.TrimSpace(t[i+1:])
+	}
+	num, err := strconv.Atoi(numText)
+	if err != nil || num <= 0 {
+		return nil, ParseError{Text: text, Pos: strings.Index(text, numText), Msg: fmt.Sprintf("invalid numerator %q, expecting positive integer", numText)}
+	}
+	den, err := strconv.Atoi(denText)
+	if err != nil || den <= 0 {
+		return nil, ParseError{Text: text, Pos: strings.LastIndex(text, denText), Msg: fmt.Sprintf("invalid denominator %q, expecting positive integer", denText)}
+	}
+	x, p := s.rat(num, den)
+	if p != 0 {
+		return nil, ParseError{Text: text, Pos: 0, Msg: fmt.Sprintf("%d is not a generator of %s", p, s.Name())}
+	}
+	return x, nil
+}
+
+// PosRat is an Elem of a PosRatSet, a positive rational
+// stored as the exponents of its prime factors.
+//
+// The zero value is 1.
+type PosRat struct {
+	p []int // prime factors, in ascending order.
+	e []int // nonzero exponents of the prime factors.
+}
+
+// merge returns x·y^sign.
+func (x PosRat) merge(y PosRat, sign int) PosRat {
+	n := len(x.p) + len(y.p)
+	z := PosRat{p: make([]int, 0, n), e: make([]int, 0, n)}
+	j, k := 0, 0
+	for j < len(x.p) || k < len(y.p) {
+		var p, e int
+		switch {
+		case k == len(y.p) || j < len(x.p) && x.p[j] < y.p[k]:
+			p, e = x.p[j], x.e[j]
+			j++
+		case j == len(x.p) || y.p[k] < x.p[j]:
+			p, e = y.p[k], sign*y.e[k]
+			k++
+		default:
+			p, e = x.p[j], x.e[j]+sign*y.e[k]
+			j++
+			k++
+		}
+		if e != 0 {
+			z.p = append(z.p, p)
+			z.e = append(z.e, e)
+		}
+	}
+	return z
+}
+
+// Exponent returns the exponent of the prime p in x,
+// e.g. -1 for p = 3 and x = 4/3.
+func (x PosRat) Exponent(p int) int {
+	k := sort.SearchInts(x.p, p)
+	if k < len(x.p) && x.p[k] == p {
+		return x.e[k]
+	}
+	return 0
+}
+
+// Each calls f with each prime factor of x and its nonzero
+// exponent, in ascending order of prime.
+func (x PosRat) Each(f func(p, e int)) {
+	for k, p := range x.p {
+		f(p, x.e[k])
+	}
+}
+
+// Num returns the numerator of x in lowest terms.
+func (x PosRat) Num() *big.Int {
+	return x.product(1)
+}
+
+// Den returns the denominator of x in lowest terms.
+func (x PosRat) Den() *big.Int {
+	return x.product(-1)
+}
+
+// product returns the product of p^|e| for the exponents e with the given sign.
+func (x PosRat) product(sign int) *big.Int {
+	n, pe := big.NewInt(1), new(big.Int)
+	for k, p := range x.p {
+		if e := sign * x.e[k]; e > 0 {
+			n.Mul(n, pe.Exp(big.NewInt(int64(p)), big.NewInt(int64(e)), nil))
+		}
+	}
+	return n
+}
+
+// Rat returns x as a big.Rat.
+func (x PosRat) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(x.Num(), x.Den())
+}
+
+// String returns x in lowest terms, e.g. 3/4, or 6 if x is an integer.
+func (x PosRat) String() string {
+	return x.Rat().RatString()
+}
+
+// Compare returns 0 if x == y, -ve int if x < y, +ve int if x > y,
+// comparing the rationals by value.
+func (x PosRat) Compare(y Elem) int {
+	return x.Rat().Cmp(y.(PosRat).Rat())
+}
+
+// Key returns the binary encoding of the prime factors
+// and exponents of x as a string.
+func (x PosRat) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	var buf []byte
+	for k, p := range x.p {
+		buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(p))]...)
+		buf = append(buf, tmp[:binary.PutVarint(tmp[:], int64(x.e[k]))]...)
+	}
+	return string(buf)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of x.
+func (x PosRat) Hash() uint64 {
+	return hashKey(x.Key())
+}
@@ -0,0 +1,92 @@
+package set
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPosRat(t *testing.T) {
+	s := NewPosRat(0)
+	if want, got := "ℚ>0", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	x, y := s.Rat(6, 4), s.Rat(10, 9)
+	if want, got := "3/2", x.String(); want != got {
+		t.Errorf("expected 6/4 = %s but got %s", want, got)
+	}
+	if want, got := "5/3", s.Mul(x, y).String(); want != got {
+		t.Errorf("expected 3/2·10/9 = %s but got %s", want, got)
+	}
+	if want, got := "27/20", s.Div(x, y).String(); want != got {
+		t.Errorf("expected 3/2÷10/9 = %s but got %s", want, got)
+	}
+	if want, got := "2/3", s.Inverse(x).String(); want != got {
+		t.Errorf("expected 1/(3/2) = %s but got %s", want, got)
+	}
+	if want, got := s.Identity(), s.Mul(x, s.Inverse(x)); want.Compare(got) != 0 || Key(want) != Key(got) {
+		t.Errorf("expected x·1/x = %v but got %v", want, got)
+	}
+	if want, got := -1, x.Exponent(2); want != got {
+		t.Errorf("expected exponent of 2 in %v to be %d but got %d", x, want, got)
+	}
+	if !s.Less(y, x) || s.Less(x, y) {
+		t.Errorf("expected %v < %v", y, x)
+	}
+	if want, got := InfiniteRank, s.Rank(); want != got {
+		t.Errorf("expected rank of %s to be %d but got %d", s.Name(), want, got)
+	}
+}
+
+func TestPosRatSparse(t *testing.T) {
+	s := NewPosRat(3)
+	if want, got := "ℚ>0⟨2,3,5⟩", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	x, y := s.Rat(4, 3), s.Rat(5, 8)
+	if want, got := "(2,-1,0)", s.ToSparse(x).String(); want != got {
+		t.Errorf("expected exponents of %v to be %s but got %s", x, want, got)
+	}
+	sum := s.Sparse().Add(s.ToSparse(x), s.ToSparse(y))
+	if want, got := s.ToSparse(s.Mul(x, y)), sum; want.Compare(got) != 0 {
+		t.Errorf("expected exponents of x·y to be %v but got %v", want, got)
+	}
+	if want, got := s.Mul(x, y), s.FromSparse(sum.(SparseIntTuple)); want.Compare(got) != 0 {
+		t.Errorf("expected %v but got %v", want, got)
+	}
+	if s.IsIn(NewPosRat(0).Rat(7, 1)) {
+		t.Errorf("expected 7 not to be in %s", s.Name())
+	}
+}
+
+func TestPosRatParseElem(t *testing.T) {
+	s := NewPosRat(2)
+	x, err := s.ParseElem(" 12/18")
+	if err != nil {
+		t.Fatalf("cannot parse: %v", err)
+	}
+	if want, got := "2/3", x.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	for _, text := range []string{"0", "3/-1", "5/2", "a"} {
+		if _, err := s.ParseElem(text); err == nil {
+			t.Errorf("expected parse error for %q", text)
+		}
+	}
+}
+
+func TestFactor(t *testing.T) {
+	for n, want := range map[int]string{
+		1:                   "map[]",
+		360:                 "map[2:3 3:2 5:1]",
+		4 * 1000000007:      "map[2:2 1000000007:1]",
+		math.MaxInt64:       "map[7:2 73:1 127:1 337:1 92737:1 649657:1]",
+		4052555153018976267: "map[3:39]",
+	} {
+		exps := make(map[int]int)
+		factor(n, exps, 1)
+		if got := fmt.Sprint(exps); want != got {
+			t.Errorf("expected factors of %d to be %s but got %s", n, want, got)
+		}
+	}
+}
@@ -18,3 +18,66 @@ type PartialOrdered interface {
 type StrictOrdered interface {
 	Less(x, y set.Elem) bool
 }
+
+// Invertible is the property where every element
+// of the set has an inverse (x⁻¹ defined).
+type Invertible interface {
+	Inverse(x set.Elem) set.Elem
+}
+
+// TotallyOrdered is the property where any two elements
+// are comparable, i.e. exactly one of x < y, x = y, x > y holds.
+type TotallyOrdered interface {
+	StrictOrdered
+	LessEqual(x, y set.Elem) bool
+	Equal(x, y set.Elem) bool
+}
+
+// Finite is the property where the set has
+// a finite number of elements.
+type Finite interface {
+	Cardinality() int
+}
+
+// Countable is the property where the elements can be
+// listed in a sequence, which does not end if the set is infinite.
+type Countable interface {
+	Sequence() set.Nexter
+}
+
+// Bounded is the property where the set has a least
+// and a greatest element (with respect to ≤).
+type Bounded interface {
+	Min() set.Elem
+	Max() set.Elem
+}
+
+// Lattice is the property where every two elements have
+// a greatest lower bound (meet, ∧) and a least upper bound (join, ∨)
+// with respect to a partial order contained in ≤, e.g. the componentwise
+// order of ℤⁿ, whose intervals are boxes, within its lexicographic ≤.
+type Lattice interface {
+	Meet(x, y set.Elem) set.Elem
+	Join(x, y set.Elem) set.Elem
+}
+
+// Discrete is the property where every element has an immediate
+// successor and predecessor with respect to <, e.g. x+1 and x-1 in ℤ.
+type Discrete interface {
+	Succ(x set.Elem) set.Elem
+	Pred(x set.Elem) set.Elem
+}
+
+// TorsionFree is the property where no element other than the
+// identity has finite order, i.e. x·x·…·x ≠ e for x ≠ e.
+//
+// Rank is the torsion-free rank, the size of a maximal
+// independent subset of elements, e.g. n for ℤⁿ, or InfiniteRank
+// if there is no finite maximal independent subset, e.g. for ℚ>0.
+type TorsionFree interface {
+	Rank() int
+}
+
+// InfiniteRank is the Rank of a TorsionFree set of infinite rank.
+// Callers of Rank must check for it before using the rank as a size.
+const InfiniteRank = set.InfiniteRank
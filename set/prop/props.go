@@ -0,0 +1,88 @@
+package prop
+
+import (
+	"strings"
+
+	"github.com/nickng/abelian/set"
+)
+
+// Properties is a set of the property interfaces of this package.
+type Properties uint
+
+// The properties, one for each property interface.
+const (
+	IsPartialOrdered Properties = 1 << iota
+	IsStrictOrdered
+	IsTotallyOrdered
+	IsInvertible
+	IsFinite
+	IsCountable
+	IsBounded
+	IsLattice
+	IsDiscrete
+	IsTorsionFree
+)
+
+var propertyNames = []string{
+	"PartialOrdered",
+	"StrictOrdered",
+	"TotallyOrdered",
+	"Invertible",
+	"Finite",
+	"Countable",
+	"Bounded",
+	"Lattice",
+	"Discrete",
+	"TorsionFree",
+}
+
+// Of returns the properties implemented by the set s.
+func Of(s set.Set) Properties {
+	var p Properties
+	check := func(q Properties, ok bool) {
+		if ok {
+			p |= q
+		}
+	}
+	_, ok := s.(PartialOrdered)
+	check(IsPartialOrdered, ok)
+	_, ok = s.(StrictOrdered)
+	check(IsStrictOrdered, ok)
+	_, ok = s.(TotallyOrdered)
+	check(IsTotallyOrdered, ok)
+	_, ok = s.(Invertible)
+	check(IsInvertible, ok)
+	_, ok = s.(Finite)
+	check(IsFinite, ok)
+	_, ok = s.(Countable)
+	check(IsCountable, ok)
+	_, ok = s.(Bounded)
+	check(IsBounded, ok)
+	_, ok = s.(Lattice)
+	check(IsLattice, ok)
+	_, ok = s.(Discrete)
+	check(IsDiscrete, ok)
+	_, ok = s.(TorsionFree)
+	check(IsTorsionFree, ok)
+	return p
+}
+
+// Has returns true if p includes all the properties of q.
+func (p Properties) Has(q Properties) bool {
+	return p&q == q
+}
+
+// String returns the names of the properties separated by |,
+// e.g. PartialOrdered|Invertible.
+func (p Properties) String() string {
+	var names []string
+	for i, name := range propertyNames {
+		if p&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
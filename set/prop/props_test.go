@@ -0,0 +1,40 @@
+package prop_test
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+func TestOf(t *testing.T) {
+	s := set.NewIntTuple(2)
+	tests := []struct {
+		s    set.Set
+		want prop.Properties
+	}{
+		{s, prop.IsPartialOrdered | prop.IsStrictOrdered | prop.IsTotallyOrdered | prop.IsInvertible |
+			prop.IsCountable | prop.IsLattice | prop.IsDiscrete | prop.IsTorsionFree},
+		{set.NewMod(4), prop.IsInvertible | prop.IsFinite | prop.IsCountable},
+		{s.Interval(s.Tuple(0, 0), s.Tuple(1, 1)).(set.IntTupleInterval), prop.IsFinite | prop.IsCountable | prop.IsBounded},
+		{set.NewFiniteSet(s), prop.IsFinite},
+	}
+	for _, test := range tests {
+		if want, got := test.want, prop.Of(test.s); want != got {
+			t.Errorf("expected properties of %s to be %v but got %v", test.s.Name(), want, got)
+		}
+	}
+}
+
+func TestProperties(t *testing.T) {
+	p := prop.IsInvertible | prop.IsFinite
+	if !p.Has(prop.IsInvertible) || !p.Has(prop.IsInvertible|prop.IsFinite) || p.Has(prop.IsFinite|prop.IsBounded) {
+		t.Errorf("unexpected Has for %v", p)
+	}
+	if want, got := "Invertible|Finite", p.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "none", prop.Properties(0).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
@@ -3,6 +3,8 @@
 // Package set implements a Set data structure.
 package set
 
+//go:generate go run gen_tuples.go
+
 // Set is a generic set.
 type Set interface {
 	// IsIn tests if the Elem x is a member of the set.
@@ -0,0 +1,276 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SparseIntTupleSet is a set of tuples of Integers (ℤx...xℤ) which
+// stores only the nonzero coordinates of its tuples.
+//
+// It has the same semantics as IntTupleSet of the same size, but
+// the cost of its operations depends on the number of nonzero
+// coordinates instead of the tuple size, e.g. for ℤ^10000.
+type SparseIntTupleSet int
+
+// NewSparseIntTuple returns a new sparse integer tuple set
+// with the specified tuple size.
+func NewSparseIntTuple(size int) SparseIntTupleSet {
+	return SparseIntTupleSet(size)
+}
+
+// Tuple creates a tuple from the nonzero coordinates in entries,
+// which maps the index of a coordinate to its value.
+//
+// The indices must be in the range [0, s.Size()), otherwise
+// it throws a runtime error.
+func (s SparseIntTupleSet) Tuple(entries map[int]int) SparseIntTuple {
+	e := SparseIntTuple{size: s.Size()}
+	for i, v := range entries {
+		if i < 0 || i >= s.Size() {
+			log.Fatalf("cannot create sparse tuple/%d with coordinate %d", s.Size(), i)
+		}
+		if v != 0 {
+			e.idx = append(e.idx, i)
+		}
+	}
+	sort.Ints(e.idx)
+	e.val = make([]int, len(e.idx))
+	for k, i := range e.idx {
+		e.val[k] = entries[i]
+	}
+	return e
+}
+
+// FromDense returns the sparse tuple with the coordinates of x.
+func (s SparseIntTupleSet) FromDense(x IntTuple) SparseIntTuple {
+	if x.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: x.Size(), Dim2: s.Size()})
+	}
+	e := SparseIntTuple{size: s.Size()}
+	for i, v := range x {
+		if v != 0 {
+			e.idx = append(e.idx, i)
+			e.val = append(e.val, v)
+		}
+	}
+	return e
+}
+
+// Identity returns the identity of the set, the tuple (0,0...)
+// which has no nonzero coordinates.
+func (s SparseIntTupleSet) Identity() Elem {
+	return SparseIntTuple{size: s.Size()}
+}
+
+// IsIn returns true if x ∈ s.
+func (s SparseIntTupleSet) IsIn(x Elem) bool {
+	xElem, ok := x.(SparseIntTuple)
+	if !ok {
+		return false
+	}
+	return s.Size() == xElem.Size()
+}
+
+// Size returns the tuple size of the set.
+func (s SparseIntTupleSet) Size() int {
+	return int(s)
+}
+
+// Rank returns the torsion-free rank of the set, i.e. its tuple size.
+func (s SparseIntTupleSet) Rank() int {
+	return s.Size()
+}
+
+// Name returns the formal name of the set, e.g. ℤ^10000.
+func (s SparseIntTupleSet) Name() string {
+	if s.Size() <= 1 {
+		return NewIntTuple(s.Size()).Name()
+	}
+	return fmt.Sprintf("ℤ^%d", s.Size())
+}
+
+// Add is the + binary operation. It returns x + y.
+func (s SparseIntTupleSet) Add(x, y Elem) Elem {
+	return s.merge(x, y, 1)
+}
+
+// Sub is the - binary operation. It returns x - y.
+func (s SparseIntTupleSet) Sub(x, y Elem) Elem {
+	return s.merge(x, y, -1)
+}
+
+// Inverse returns the additive inverse of x, i.e. -x.
+func (s SparseIntTupleSet) Inverse(x Elem) Elem {
+	return s.merge(s.Identity(), x, -1)
+}
+
+// merge returns x + sign·y, which have the same size as s.
+func (s SparseIntTupleSet) merge(x, y Elem, sign int) SparseIntTuple {
+	xElem, yElem := x.(SparseIntTuple), y.(SparseIntTuple)
+	if xElem.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: xElem.Size(), Dim2: s.Size()})
+	}
+	if yElem.Size() != s.Size() {
+		log.Fatal(MismatchDimErr{Dim1: yElem.Size(), Dim2: s.Size()})
+	}
+	n := len(xElem.idx) + len(yElem.idx)
+	z := SparseIntTuple{size: s.Size(), idx: make([]int, 0, n), val: make([]int, 0, n)}
+	j, k := 0, 0
+	for j < len(xElem.idx) || k < len(yElem.idx) {
+		var i, v int
+		switch {
+		case k == len(yElem.idx) || j < len(xElem.idx) && xElem.idx[j] < yElem.idx[k]:
+			i, v = xElem.idx[j], xElem.val[j]
+			j++
+		case j == len(xElem.idx) || yElem.idx[k] < xElem.idx[j]:
+			i, v = yElem.idx[k], sign*yElem.val[k]
+			k++
+		default:
+			i, v = xElem.idx[j], xElem.val[j]+sign*yElem.val[k]
+			j++
+			k++
+		}
+		if v != 0 {
+			z.idx = append(z.idx, i)
+			z.val = append(z.val, v)
+		}
+	}
+	return z
+}
+
+// Less returns x < y.
+func (s SparseIntTupleSet) Less(x, y Elem) bool {
+	return x.Compare(y) < 0
+}
+
+// LessEqual returns x ≤ y.
+func (s SparseIntTupleSet) LessEqual(x, y Elem) bool {
+	return x.Compare(y) <= 0
+}
+
+// Equal returns x = y.
+func (s SparseIntTupleSet) Equal(x, y Elem) bool {
+	return x.Compare(y) == 0
+}
+
+// SparseIntTuple is an Elem in a SparseIntTupleSet.
+//
+// The zero value is the empty tuple ().
+type SparseIntTuple struct {
+	size int
+	idx  []int // indices of the nonzero coordinates, in ascending order.
+	val  []int // values of the nonzero coordinates.
+}
+
+// Size returns the tuple size of e.
+func (e SparseIntTuple) Size() int {
+	return e.size
+}
+
+// NonZero returns the number of nonzero coordinates of e.
+func (e SparseIntTuple) NonZero() int {
+	return len(e.idx)
+}
+
+// At returns the i-th coordinate of e.
+func (e SparseIntTuple) At(i int) int {
+	if i < 0 || i >= e.size {
+		log.Fatalf("coordinate %d out of range of tuple/%d", i, e.size)
+	}
+	k := sort.SearchInts(e.idx, i)
+	if k < len(e.idx) && e.idx[k] == i {
+		return e.val[k]
+	}
+	return 0
+}
+
+// Each calls f with the index and value of each nonzero
+// coordinate of e, in ascending order of index.
+func (e SparseIntTuple) Each(f func(i, v int)) {
+	for k, i := range e.idx {
+		f(i, e.val[k])
+	}
+}
+
+// Dense returns e as an IntTuple.
+func (e SparseIntTuple) Dense() IntTuple {
+	x := make(IntTuple, e.size)
+	for k, i := range e.idx {
+		x[i] = e.val[k]
+	}
+	return x
+}
+
+// String returns a numeric/tuple representation set element e,
+// the same as the String of the dense IntTuple.
+func (e SparseIntTuple) String() string {
+	if e.Size() == 1 {
+		return strconv.Itoa(e.At(0)) // integer
+	}
+	var buf strings.Builder
+	buf.WriteRune('(')
+	k := 0
+	for i := 0; i < e.size; i++ {
+		if i != 0 {
+			buf.WriteRune(',')
+		}
+		if k < len(e.idx) && e.idx[k] == i {
+			buf.WriteString(strconv.Itoa(e.val[k]))
+			k++
+		} else {
+			buf.WriteRune('0')
+		}
+	}
+	buf.WriteRune(')')
+	return buf.String()
+}
+
+// Compare returns 0 if e == x, -ve int if e < x, +ve int if e > x,
+// comparing lexicographically as the dense IntTuple.
+func (e SparseIntTuple) Compare(x Elem) int {
+	tuple := x.(SparseIntTuple)
+	j, k := 0, 0
+	for j < len(e.idx) || k < len(tuple.idx) {
+		var v, w int
+		switch {
+		case k == len(tuple.idx) || j < len(e.idx) && e.idx[j] < tuple.idx[k]:
+			v = e.val[j]
+			j++
+		case j == len(e.idx) || tuple.idx[k] < e.idx[j]:
+			w = tuple.val[k]
+			k++
+		default:
+			v, w = e.val[j], tuple.val[k]
+			j++
+			k++
+		}
+		if v < w {
+			return -1
+		} else if v > w {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Key returns the binary encoding of the size and the nonzero
+// coordinates of e as a string.
+func (e SparseIntTuple) Key() string {
+	var tmp [binary.MaxVarintLen64]byte
+	buf := append([]byte(nil), tmp[:binary.PutUvarint(tmp[:], uint64(e.size))]...)
+	for k, i := range e.idx {
+		buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(i))]...)
+		buf = append(buf, tmp[:binary.PutVarint(tmp[:], int64(e.val[k]))]...)
+	}
+	return string(buf)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the Key of e.
+func (e SparseIntTuple) Hash() uint64 {
+	return hashKey(e.Key())
+}
@@ -0,0 +1,90 @@
+package set
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSparseIntTuple(t *testing.T) {
+	s := NewSparseIntTuple(10000)
+	x := s.Tuple(map[int]int{3: 5, 9999: -1, 42: 0})
+	y := s.Tuple(map[int]int{3: -5, 7: 2})
+	if want, got := 2, x.NonZero(); want != got {
+		t.Errorf("expected %d nonzero coordinates but got %d", want, got)
+	}
+	z := s.Add(x, y).(SparseIntTuple)
+	if want, got := 2, z.NonZero(); want != got {
+		t.Errorf("expected %d nonzero coordinates in %v but got %d", want, z.Dense()[:10], got)
+	}
+	if want, got := 2, z.At(7); want != got {
+		t.Errorf("expected coordinate 7 to be %d but got %d", want, got)
+	}
+	if want, got := s.Identity(), s.Add(x, s.Inverse(x)); !s.Equal(want, got) {
+		t.Errorf("expected x + -x to be the identity")
+	}
+	if want, got := "ℤ^10000", s.Name(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if !s.IsIn(x) || s.IsIn(NewSparseIntTuple(3).Identity()) || s.IsIn(IntTuple{1}) {
+		t.Errorf("unexpected membership of %s", s.Name())
+	}
+}
+
+func TestSparseIntTupleString(t *testing.T) {
+	if want, got := "(0,2,0,-1)", NewSparseIntTuple(4).Tuple(map[int]int{1: 2, 3: -1}).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "-3", NewSparseIntTuple(1).Tuple(map[int]int{0: -3}).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "()", NewSparseIntTuple(0).Identity().String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+// Tests sparse tuples have the same semantics as dense tuples.
+func TestSparseIntTupleDense(t *testing.T) {
+	const size = 6
+	d, s := NewIntTuple(size), NewSparseIntTuple(size)
+	rnd := rand.New(rand.NewSource(1))
+	random := func() IntTuple {
+		x := make(IntTuple, size)
+		for i := range x {
+			if rnd.Intn(3) == 0 {
+				x[i] = rnd.Intn(5) - 2
+			}
+		}
+		return x
+	}
+	for n := 0; n < 1000; n++ {
+		x, y := random(), random()
+		sx, sy := s.FromDense(x), s.FromDense(y)
+		if want, got := x, sx.Dense(); want.Compare(got) != 0 {
+			t.Fatalf("expected %v but got %v", want, got)
+		}
+		if want, got := x.String(), sx.String(); want != got {
+			t.Errorf("expected %s but got %s", want, got)
+		}
+		if want, got := x.Compare(y), sx.Compare(sy); want != got {
+			t.Errorf("expected %v compared with %v to be %d but got %d", x, y, want, got)
+		}
+		if want, got := d.Add(x, y).(IntTuple), s.Add(sx, sy).(SparseIntTuple).Dense(); want.Compare(got) != 0 {
+			t.Errorf("expected %v + %v to be %v but got %v", x, y, want, got)
+		}
+		if want, got := d.Sub(x, y).(IntTuple), s.Sub(sx, sy).(SparseIntTuple).Dense(); want.Compare(got) != 0 {
+			t.Errorf("expected %v - %v to be %v but got %v", x, y, want, got)
+		}
+		if want, got := x.Compare(y) == 0, sx.Key() == sy.Key(); want != got {
+			t.Errorf("expected keys of %v and %v to be equal: %t", x, y, want)
+		}
+	}
+}
+
+func BenchmarkSparseIntTupleAdd(b *testing.B) {
+	s := NewSparseIntTuple(10000)
+	x, y := s.Tuple(map[int]int{1: 1, 500: 2, 9000: 3}), s.Tuple(map[int]int{500: 1, 7000: 4})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(x, y)
+	}
+}
@@ -0,0 +1,101 @@
+package stream
+
+import (
+	"errors"
+	"log"
+	"sort"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+)
+
+// ErrNoSession is the error returned when retracting
+// an event which does not belong to any open session.
+var ErrNoSession = errors.New("stream: no open session for retraction")
+
+// Session aggregates events into sessions, windows of events where
+// consecutive events are less than gap apart.
+//
+// A session covers [first event, last event + 1). Events arriving out of
+// order can extend sessions or merge neighbouring sessions.
+type Session struct {
+	g        abelian.Group
+	gap      int64
+	sessions []Window // in ascending order of time.
+}
+
+// NewSession returns a new session window aggregator
+// with the given inactivity gap.
+//
+// The Set of g must implement prop.Invertible for retractions,
+// otherwise abelian.ErrNotInvertible is returned.
+func NewSession(g abelian.Group, gap int64) (*Session, error) {
+	if err := checkInvertible(g); err != nil {
+		return nil, err
+	}
+	if gap <= 0 {
+		log.Fatalf("cannot create session windows with gap %d", gap)
+	}
+	return &Session{g: g, gap: gap}, nil
+}
+
+// Add adds the event with value v at time t, merging all the sessions
+// which are less than gap away from t.
+func (a *Session) Add(t int64, v set.Elem) {
+	merged := Window{Start: t, End: t + 1, Total: v}
+	// First session which does not end before t - gap.
+	i := sort.Search(len(a.sessions), func(i int) bool { return a.sessions[i].End-1+a.gap > t })
+	j := i
+	for ; j < len(a.sessions) && a.sessions[j].Start-a.gap < t; j++ {
+		s := a.sessions[j]
+		if s.Start < merged.Start {
+			merged.Start = s.Start
+		}
+		if s.End > merged.End {
+			merged.End = s.End
+		}
+		merged.Total = a.g.Op(s.Total, merged.Total)
+	}
+	a.sessions = append(a.sessions[:i], append([]Window{merged}, a.sessions[j:]...)...)
+}
+
+// Retract removes the event with value v at time t by adding the
+// inverse of v to the session covering t. Sessions are not split
+// by retractions.
+//
+// If no open session covers t, ErrNoSession is returned.
+func (a *Session) Retract(t int64, v set.Elem) error {
+	i := sort.Search(len(a.sessions), func(i int) bool { return a.sessions[i].End > t })
+	if i == len(a.sessions) || a.sessions[i].Start > t {
+		return ErrNoSession
+	}
+	a.sessions[i].Total = a.g.Op(a.sessions[i].Total, inverse(a.g, v))
+	return nil
+}
+
+// Sessions returns the open sessions in ascending order of time.
+func (a *Session) Sessions() []Window {
+	return cloneWindows(a.sessions)
+}
+
+// Evict closes and returns the sessions which can no longer grow, i.e.
+// the watermark is at least gap after their last event.
+func (a *Session) Evict(watermark int64) []Window {
+	n := 0
+	for n < len(a.sessions) && a.sessions[n].End-1+a.gap <= watermark {
+		n++
+	}
+	closed := cloneWindows(a.sessions[:n])
+	a.sessions = a.sessions[n:]
+	return closed
+}
+
+// Snapshot returns the state of the open sessions.
+func (a *Session) Snapshot() Snapshot {
+	return Snapshot{Windows: a.Sessions()}
+}
+
+// Restore replaces the state with the snapshot s.
+func (a *Session) Restore(s Snapshot) {
+	a.sessions = cloneWindows(s.Windows)
+}
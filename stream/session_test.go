@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	a, err := NewSession(group(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Add(0, ints.Tuple(1))
+	a.Add(3, ints.Tuple(2))
+	a.Add(20, ints.Tuple(4))
+	a.Add(12, ints.Tuple(8))
+	ss := a.Sessions()
+	if want, got := 3, len(ss); want != got {
+		t.Fatalf("expected %d sessions but got %d: %v", want, got, ss)
+	}
+	if ss[0].Start != 0 || ss[0].End != 4 {
+		t.Errorf("expected session [0,4) but got [%d,%d)", ss[0].Start, ss[0].End)
+	}
+	checkTotal(t, "[0,4)", 3, ss[0].Total)
+
+	// Out-of-order event merging [12,13) and [20,21).
+	a.Add(16, ints.Tuple(16))
+	ss = a.Sessions()
+	if want, got := 2, len(ss); want != got {
+		t.Fatalf("expected %d sessions but got %d: %v", want, got, ss)
+	}
+	if ss[1].Start != 12 || ss[1].End != 21 {
+		t.Errorf("expected session [12,21) but got [%d,%d)", ss[1].Start, ss[1].End)
+	}
+	checkTotal(t, "[12,21)", 28, ss[1].Total)
+
+	if err := a.Retract(16, ints.Tuple(16)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	checkTotal(t, "retract 16", 12, a.Sessions()[1].Total)
+	if err := a.Retract(8, ints.Tuple(1)); err != ErrNoSession {
+		t.Errorf("expected %v but got %v", ErrNoSession, err)
+	}
+
+	snap := a.Snapshot()
+	closed := a.Evict(10)
+	if want, got := 1, len(closed); want != got {
+		t.Fatalf("expected %d closed sessions but got %d", want, got)
+	}
+	checkTotal(t, "closed", 3, closed[0].Total)
+	a.Restore(snap)
+	if want, got := 2, len(a.Sessions()); want != got {
+		t.Errorf("expected %d sessions after restore but got %d", want, got)
+	}
+}
+
+func TestSessionGap(t *testing.T) {
+	a, _ := NewSession(group(), 5)
+	a.Add(0, ints.Tuple(1))
+	a.Add(5, ints.Tuple(1)) // exactly gap apart: new session.
+	a.Add(9, ints.Tuple(1)) // less than gap apart.
+	if want, got := 2, len(a.Sessions()); want != got {
+		t.Errorf("expected %d sessions but got %d: %v", want, got, a.Sessions())
+	}
+	a.Add(-4, ints.Tuple(1))
+	if want, got := 2, len(a.Sessions()); want != got {
+		t.Errorf("expected %d sessions but got %d: %v", want, got, a.Sessions())
+	}
+	checkTotal(t, "first", 2, a.Sessions()[0].Total)
+}
@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"log"
+	"sort"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+)
+
+// Sliding keeps the running total of the events in the sliding window
+// (now - length, now].
+//
+// Events after now are kept until the window reaches them, and events
+// which leave the window are removed by applying their inverse.
+type Sliding struct {
+	g      abelian.Group
+	length int64
+	now    int64
+	total  set.Elem
+	events []Event // events after now - length, in ascending order of time.
+}
+
+// NewSliding returns a new sliding window aggregator with a window of
+// the given length, starting at time 0.
+//
+// The Set of g must implement prop.Invertible to remove expired events,
+// otherwise abelian.ErrNotInvertible is returned.
+func NewSliding(g abelian.Group, length int64) (*Sliding, error) {
+	if err := checkInvertible(g); err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		log.Fatalf("cannot create sliding window of length %d", length)
+	}
+	return &Sliding{g: g, length: length, total: g.Identity()}, nil
+}
+
+// Now returns the current time of the window.
+func (a *Sliding) Now() int64 {
+	return a.now
+}
+
+// Total returns the total of the events in the window.
+func (a *Sliding) Total() set.Elem {
+	return a.total
+}
+
+// Add adds the event with value v at time t, and returns false if the
+// event is too late, i.e. it has already left the window.
+//
+// Events can arrive out of order, events after now are added
+// to the total when the window is advanced to them.
+func (a *Sliding) Add(t int64, v set.Elem) bool {
+	if t <= a.now-a.length {
+		return false
+	}
+	i := sort.Search(len(a.events), func(i int) bool { return a.events[i].Time > t })
+	a.events = append(a.events, Event{})
+	copy(a.events[i+1:], a.events[i:])
+	a.events[i] = Event{Time: t, Value: v}
+	if t <= a.now {
+		a.total = a.g.Op(a.total, v)
+	}
+	return true
+}
+
+// Retract removes the event with value v at time t by adding the inverse
+// of v, and returns false if the event has already left the window.
+func (a *Sliding) Retract(t int64, v set.Elem) bool {
+	return a.Add(t, inverse(a.g, v))
+}
+
+// Advance moves the window to (now - length, now]. The window cannot
+// move backwards in time.
+func (a *Sliding) Advance(now int64) {
+	if now < a.now {
+		log.Fatalf("cannot advance sliding window back from %d to %d", a.now, now)
+	}
+	expired := 0
+	for _, e := range a.events {
+		if e.Time > now {
+			break
+		}
+		if e.Time > a.now { // entering the window.
+			a.total = a.g.Op(a.total, e.Value)
+		}
+		if e.Time <= now-a.length { // leaving the window.
+			a.total = a.g.Op(a.total, inverse(a.g, e.Value))
+			expired++
+		}
+	}
+	a.events = a.events[expired:]
+	a.now = now
+}
+
+// Window returns the current window.
+func (a *Sliding) Window() Window {
+	return Window{Start: a.now - a.length + 1, End: a.now + 1, Total: a.total}
+}
+
+// Snapshot returns the state of the window.
+func (a *Sliding) Snapshot() Snapshot {
+	events := make([]Event, len(a.events))
+	copy(events, a.events)
+	return Snapshot{Now: a.now, Windows: []Window{a.Window()}, Events: events}
+}
+
+// Restore replaces the state with the snapshot s.
+func (a *Sliding) Restore(s Snapshot) {
+	a.now = s.Now
+	a.total = a.g.Identity()
+	if len(s.Windows) > 0 {
+		a.total = s.Windows[0].Total
+	}
+	a.events = make([]Event, len(s.Events))
+	copy(a.events, s.Events)
+}
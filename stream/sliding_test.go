@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nickng/abelian/set"
+)
+
+func TestSliding(t *testing.T) {
+	a, err := NewSliding(group(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Add(3, ints.Tuple(5))
+	a.Add(8, ints.Tuple(2))
+	a.Advance(8)
+	checkTotal(t, "now=8", 7, a.Total())
+	a.Advance(15)
+	checkTotal(t, "now=15", 2, a.Total())
+	if a.Add(5, ints.Tuple(100)) {
+		t.Errorf("expected event at 5 to be too late at 15")
+	}
+	a.Add(20, ints.Tuple(1)) // future event.
+	checkTotal(t, "now=15 with future", 2, a.Total())
+	a.Retract(8, ints.Tuple(2))
+	checkTotal(t, "retract 8", 0, a.Total())
+	a.Advance(20)
+	checkTotal(t, "now=20", 1, a.Total())
+	if w := a.Window(); w.Start != 11 || w.End != 21 {
+		t.Errorf("expected window [11,21) but got [%d,%d)", w.Start, w.End)
+	}
+}
+
+// TestSlidingRandom checks the running total against recomputing
+// the total of the window from all events.
+func TestSlidingRandom(t *testing.T) {
+	a, _ := NewSliding(group(), 7)
+	rnd := rand.New(rand.NewSource(1))
+	var all []Event
+	now := int64(0)
+	for i := 0; i < 500; i++ {
+		switch rnd.Intn(3) {
+		case 0:
+			now += int64(rnd.Intn(4))
+			a.Advance(now)
+		default:
+			e := Event{Time: now + int64(rnd.Intn(12)-6), Value: ints.Tuple(rnd.Intn(10) - 3)}
+			if a.Add(e.Time, e.Value) {
+				all = append(all, e)
+			}
+		}
+		want := 0
+		for _, e := range all {
+			if now-7 < e.Time && e.Time <= now {
+				want += e.Value.(set.IntTuple)[0]
+			}
+		}
+		checkTotal(t, "random", want, a.Total())
+	}
+}
+
+func TestSlidingSnapshot(t *testing.T) {
+	a, _ := NewSliding(group(), 5)
+	a.Add(1, ints.Tuple(1))
+	a.Add(4, ints.Tuple(2))
+	a.Add(9, ints.Tuple(4))
+	a.Advance(4)
+	snap := a.Snapshot()
+	a.Advance(9)
+	checkTotal(t, "now=9", 4, a.Total())
+
+	b, _ := NewSliding(group(), 5)
+	b.Restore(snap)
+	checkTotal(t, "restored", 3, b.Total())
+	b.Advance(9)
+	checkTotal(t, "restored now=9", 4, b.Total())
+}
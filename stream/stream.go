@@ -0,0 +1,76 @@
+// Package stream implements streaming aggregation of events
+// over tumbling, sliding and session windows, where the values
+// of events are elements of an abelian group.
+//
+// Because the group operation is commutative and invertible,
+// events can arrive out of order, retractions are applied as the
+// inverse of a value, and expired events are removed from a running
+// total by applying their inverse instead of recomputing the total.
+//
+//	v := set.NewIntTuple(1)
+//	w, _ := stream.NewSliding(abelian.New(v, v.Add), 10)
+//	w.Add(3, v.Tuple(5))
+//	w.Add(8, v.Tuple(2))
+//	w.Advance(15)
+//	w.Total() // 2, the event at time 3 has expired.
+package stream
+
+import (
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+	"github.com/nickng/abelian/set/prop"
+)
+
+// Event is a value at a point in time.
+type Event struct {
+	Time  int64
+	Value set.Elem
+}
+
+// Window is the total of the events in the time range [Start, End).
+type Window struct {
+	Start, End int64
+	Total      set.Elem
+}
+
+// Snapshot is the state of an aggregator, which can be restored later.
+type Snapshot struct {
+	// Now is the current time of a sliding window.
+	Now int64
+
+	// Windows are the open windows of tumbling or session windows,
+	// or the current window of a sliding window.
+	Windows []Window
+
+	// Events are the events in or after a sliding window.
+	Events []Event
+}
+
+// checkInvertible returns abelian.ErrNotInvertible
+// if the Set of g does not implement prop.Invertible.
+func checkInvertible(g abelian.Group) error {
+	if _, ok := g.Set.(prop.Invertible); !ok {
+		return abelian.ErrNotInvertible
+	}
+	return nil
+}
+
+// inverse returns the inverse of x in g, which must be invertible.
+func inverse(g abelian.Group, x set.Elem) set.Elem {
+	return g.Set.(prop.Invertible).Inverse(x)
+}
+
+// floorDiv returns ⌊a/b⌋ for b > 0.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+func cloneWindows(ws []Window) []Window {
+	c := make([]Window, len(ws))
+	copy(c, ws)
+	return c
+}
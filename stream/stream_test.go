@@ -0,0 +1,44 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/internal/settest"
+	"github.com/nickng/abelian/set"
+)
+
+var ints = set.NewIntTuple(1)
+
+func group() abelian.Group {
+	return abelian.New(ints, ints.Add)
+}
+
+func checkTotal(t *testing.T, what string, want int, got set.Elem) {
+	t.Helper()
+	if ints.Tuple(want).Compare(got) != 0 {
+		t.Errorf("%s: expected total %d but got %s", what, want, got)
+	}
+}
+
+func TestNotInvertible(t *testing.T) {
+	g := abelian.New(settest.NonInvertible{IntTupleSet: ints}, ints.Add)
+	if _, err := NewTumbling(g, 10); err != abelian.ErrNotInvertible {
+		t.Errorf("expected %v but got %v", abelian.ErrNotInvertible, err)
+	}
+	if _, err := NewSliding(g, 10); err != abelian.ErrNotInvertible {
+		t.Errorf("expected %v but got %v", abelian.ErrNotInvertible, err)
+	}
+	if _, err := NewSession(g, 10); err != abelian.ErrNotInvertible {
+		t.Errorf("expected %v but got %v", abelian.ErrNotInvertible, err)
+	}
+}
+
+func TestFloorDiv(t *testing.T) {
+	tests := []struct{ a, b, want int64 }{{7, 5, 1}, {5, 5, 1}, {0, 5, 0}, {-1, 5, -1}, {-5, 5, -1}, {-6, 5, -2}}
+	for _, tt := range tests {
+		if got := floorDiv(tt.a, tt.b); tt.want != got {
+			t.Errorf("floorDiv(%d, %d): expected %d but got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
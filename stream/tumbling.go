@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"log"
+	"sort"
+
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+)
+
+// Tumbling aggregates events into fixed-size, non-overlapping windows
+// [k·size, (k+1)·size).
+type Tumbling struct {
+	g       abelian.Group
+	size    int64
+	windows map[int64]set.Elem // totals by window start.
+}
+
+// NewTumbling returns a new tumbling window aggregator
+// with windows of the given size.
+//
+// The Set of g must implement prop.Invertible for retractions,
+// otherwise abelian.ErrNotInvertible is returned.
+func NewTumbling(g abelian.Group, size int64) (*Tumbling, error) {
+	if err := checkInvertible(g); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		log.Fatalf("cannot create tumbling windows of size %d", size)
+	}
+	return &Tumbling{g: g, size: size, windows: make(map[int64]set.Elem)}, nil
+}
+
+// Add adds the event with value v at time t to its window,
+// which may be any open window (events can arrive out of order).
+func (a *Tumbling) Add(t int64, v set.Elem) {
+	start := floorDiv(t, a.size) * a.size
+	total, ok := a.windows[start]
+	if !ok {
+		total = a.g.Identity()
+	}
+	a.windows[start] = a.g.Op(total, v)
+}
+
+// Retract removes the event with value v at time t
+// by adding the inverse of v to its window.
+func (a *Tumbling) Retract(t int64, v set.Elem) {
+	a.Add(t, inverse(a.g, v))
+}
+
+// Windows returns the open windows in ascending order of time.
+func (a *Tumbling) Windows() []Window {
+	ws := make([]Window, 0, len(a.windows))
+	for start, total := range a.windows {
+		ws = append(ws, Window{Start: start, End: start + a.size, Total: total})
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Start < ws[j].Start })
+	return ws
+}
+
+// Evict closes and returns the windows which end at or before the
+// watermark, in ascending order of time. Events for closed windows
+// start new windows if they arrive later.
+func (a *Tumbling) Evict(watermark int64) []Window {
+	var closed []Window
+	for _, w := range a.Windows() {
+		if w.End > watermark {
+			break
+		}
+		closed = append(closed, w)
+		delete(a.windows, w.Start)
+	}
+	return closed
+}
+
+// Snapshot returns the state of the open windows.
+func (a *Tumbling) Snapshot() Snapshot {
+	return Snapshot{Windows: a.Windows()}
+}
+
+// Restore replaces the state with the snapshot s.
+func (a *Tumbling) Restore(s Snapshot) {
+	a.windows = make(map[int64]set.Elem, len(s.Windows))
+	for _, w := range s.Windows {
+		a.windows[w.Start] = w.Total
+	}
+}
@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"testing"
+)
+
+func TestTumbling(t *testing.T) {
+	a, err := NewTumbling(group(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Add(3, ints.Tuple(1))
+	a.Add(15, ints.Tuple(2))
+	a.Add(-2, ints.Tuple(4))
+	a.Add(7, ints.Tuple(8)) // out of order.
+	a.Retract(15, ints.Tuple(2))
+	ws := a.Windows()
+	if want, got := 3, len(ws); want != got {
+		t.Fatalf("expected %d windows but got %d", want, got)
+	}
+	if ws[0].Start != -10 || ws[0].End != 0 {
+		t.Errorf("expected window [-10,0) but got [%d,%d)", ws[0].Start, ws[0].End)
+	}
+	checkTotal(t, "[-10,0)", 4, ws[0].Total)
+	checkTotal(t, "[0,10)", 9, ws[1].Total)
+	checkTotal(t, "[10,20)", 0, ws[2].Total)
+
+	snap := a.Snapshot()
+	closed := a.Evict(10)
+	if want, got := 2, len(closed); want != got {
+		t.Fatalf("expected %d closed windows but got %d", want, got)
+	}
+	if want, got := 1, len(a.Windows()); want != got {
+		t.Errorf("expected %d open windows but got %d", want, got)
+	}
+	a.Restore(snap)
+	if want, got := 3, len(a.Windows()); want != got {
+		t.Errorf("expected %d windows after restore but got %d", want, got)
+	}
+}
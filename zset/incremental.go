@@ -0,0 +1,87 @@
+package zset
+
+// IncJoin is the incremental version of Join.
+//
+// It keeps the integrals of both inputs, and turns the deltas of
+// the inputs into the delta of the output by bilinearity:
+//
+//	Δ(a ⋈ b) = Δa ⋈ b + a ⋈ Δb + Δa ⋈ Δb
+//
+// where a and b are the integrals before the step.
+type IncJoin struct {
+	KeyA, KeyB func(r interface{}) interface{}
+	Combine    func(x, y interface{}) interface{}
+
+	a, b ZSet
+}
+
+// Step applies the deltas da and db to the inputs and
+// returns the delta of the joined output.
+func (j *IncJoin) Step(da, db ZSet) ZSet {
+	d := Join(da, j.b, j.KeyA, j.KeyB, j.Combine)
+	d = d.Add(Join(j.a, db, j.KeyA, j.KeyB, j.Combine))
+	d = d.Add(Join(da, db, j.KeyA, j.KeyB, j.Combine))
+	j.a, j.b = j.a.Add(da), j.b.Add(db)
+	return d
+}
+
+// IncDistinct is the incremental version of Distinct.
+// The zero value is ready to use.
+//
+// Only the records changed by a delta are examined at each step.
+type IncDistinct struct {
+	integral ZSet
+}
+
+// Step applies the delta d to the input and
+// returns the delta of the distinct output.
+func (i *IncDistinct) Step(d ZSet) ZSet {
+	var out []interface{}
+	var retract []interface{}
+	for _, t := range d.sum {
+		before := i.integral.Weight(t.Sym)
+		after := before + t.Coef
+		switch {
+		case before <= 0 && after > 0:
+			out = append(out, t.Sym)
+		case before > 0 && after <= 0:
+			retract = append(retract, t.Sym)
+		}
+	}
+	i.integral = i.integral.Add(d)
+	return Of(out...).Sub(Of(retract...))
+}
+
+// IncAggregate is the incremental version of Aggregate.
+//
+// Only the groups whose keys appear in a delta are re-aggregated at
+// each step, and their previous results are retracted.
+type IncAggregate struct {
+	Key func(r interface{}) interface{}
+	Agg func(k interface{}, group ZSet) interface{}
+
+	groups map[interface{}]ZSet
+}
+
+// Step applies the delta d to the input and
+// returns the delta of the aggregated output.
+func (a *IncAggregate) Step(d ZSet) ZSet {
+	if a.groups == nil {
+		a.groups = make(map[interface{}]ZSet)
+	}
+	var out ZSet
+	for k, dg := range d.groups(a.Key) {
+		before := a.groups[k]
+		after := before.Add(dg)
+		if !before.IsZero() {
+			out = out.Sub(Of(Pair{First: k, Second: a.Agg(k, before)}))
+		}
+		if !after.IsZero() {
+			out = out.Add(Of(Pair{First: k, Second: a.Agg(k, after)}))
+			a.groups[k] = after
+		} else {
+			delete(a.groups, k)
+		}
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package zset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// deltas returns a random sequence of insertions and deletions of small integers.
+func deltas(seed int64, n int) []ZSet {
+	rnd := rand.New(rand.NewSource(seed))
+	ds := make([]ZSet, n)
+	for i := range ds {
+		for j := rnd.Intn(4); j >= 0; j-- {
+			d := Of(rnd.Intn(8))
+			if rnd.Intn(3) == 0 {
+				d = d.Neg()
+			}
+			ds[i] = ds[i].Add(d)
+		}
+	}
+	return ds
+}
+
+func TestIncJoin(t *testing.T) {
+	key := func(r interface{}) interface{} { return r.(int) % 3 }
+	das, dbs := deltas(1, 50), deltas(2, 50)
+	j := &IncJoin{KeyA: key, KeyB: key}
+	var a, b, out ZSet
+	for i := range das {
+		out = out.Add(j.Step(das[i], dbs[i]))
+		a, b = a.Add(das[i]), b.Add(dbs[i])
+		if want := Join(a, b, key, key, nil); !want.Equal(out) {
+			t.Fatalf("step %d: expected %s but got %s", i, want, out)
+		}
+	}
+}
+
+func TestIncDistinct(t *testing.T) {
+	var i IncDistinct
+	var in, out ZSet
+	for n, d := range deltas(3, 100) {
+		out = out.Add(i.Step(d))
+		in = in.Add(d)
+		if want := in.Distinct(); !want.Equal(out) {
+			t.Fatalf("step %d: expected %s but got %s", n, want, out)
+		}
+	}
+}
+
+func TestIncDistinctExample(t *testing.T) {
+	var view IncDistinct
+	if want, got := "a + b", view.Step(Of("a", "a", "b")).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if d := view.Step(Of("a").Neg()); !d.IsZero() {
+		t.Errorf("expected no change but got %s", d)
+	}
+	if want, got := "-a", view.Step(Of("a").Neg()).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestIncAggregate(t *testing.T) {
+	key := func(r interface{}) interface{} { return r.(int) % 3 }
+	value := func(r interface{}) int { return r.(int) }
+	a := &IncAggregate{Key: key, Agg: Sum(value)}
+	var in, out ZSet
+	for n, d := range deltas(4, 100) {
+		out = out.Add(a.Step(d))
+		in = in.Add(d)
+		if want := in.Aggregate(key, Sum(value)); !want.Equal(out) {
+			t.Fatalf("step %d: expected %s but got %s", n, want, out)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package zset implements Z-sets, multisets with (possibly negative)
+// integer weights, and incremental relational operators over them.
+//
+// A Z-set is an Elem of the free abelian group over records
+// (set.FreeAbelianSet), so insertions and deletions (deltas) are
+// Z-sets themselves, with positive and negative weights respectively.
+//
+// Linear operators (Filter, Map) commute with + so they can be applied
+// to deltas directly. The other operators have incremental versions
+// (IncJoin, IncDistinct, IncAggregate) which keep the integrated inputs
+// as state and turn input deltas into output deltas.
+//
+//	var view zset.IncDistinct
+//	d := view.Step(zset.Of("a", "a", "b")) // d = a + b
+//	d = view.Step(zset.Of("a").Neg())      // d = 0, a is still present
+//
+// Records must be comparable values, so they can be used as map keys,
+// and may be of mixed types, e.g. Of(2, "a"), as the symbols of
+// set.FreeAbelianSet are totally ordered across types.
+package zset
+
+import (
+	"github.com/nickng/abelian"
+	"github.com/nickng/abelian/set"
+)
+
+// Set is the free abelian group over all records.
+var Set = set.NewFreeAbelian()
+
+// Group returns the abelian group 〈Z-sets, +〉.
+func Group() abelian.Group {
+	return abelian.New(Set, Set.Add)
+}
+
+// ZSet is a multiset of records with integer weights.
+// The zero value is the empty Z-set.
+type ZSet struct {
+	sum set.FormalSum
+}
+
+// Of returns the Z-set with weight 1 for each occurrence of a record.
+func Of(records ...interface{}) ZSet {
+	terms := make([]set.Term, len(records))
+	for i, r := range records {
+		terms[i] = set.Term{Coef: 1, Sym: r}
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// FromElem returns the Z-set of the formal sum x of records.
+func FromElem(x set.Elem) ZSet {
+	return ZSet{sum: x.(set.FormalSum)}
+}
+
+// Elem returns the Z-set as a formal sum, an Elem of Set.
+func (z ZSet) Elem() set.Elem {
+	if z.sum == nil {
+		return Set.Identity()
+	}
+	return z.sum
+}
+
+// Weight returns the weight of the record r.
+func (z ZSet) Weight(r interface{}) int {
+	return z.sum.Coef(r)
+}
+
+// Records returns the records with nonzero weight in ascending order.
+func (z ZSet) Records() []interface{} {
+	return z.sum.Support()
+}
+
+// Len returns the number of records with nonzero weight.
+func (z ZSet) Len() int {
+	return len(z.sum)
+}
+
+// IsZero returns true if z is the empty Z-set.
+func (z ZSet) IsZero() bool {
+	return len(z.sum) == 0
+}
+
+// Add returns z + x.
+func (z ZSet) Add(x ZSet) ZSet {
+	return FromElem(Set.Add(z.Elem(), x.Elem()))
+}
+
+// Sub returns z - x.
+func (z ZSet) Sub(x ZSet) ZSet {
+	return FromElem(Set.Sub(z.Elem(), x.Elem()))
+}
+
+// Neg returns -z, i.e. the retraction of every record of z.
+func (z ZSet) Neg() ZSet {
+	return FromElem(Set.Inverse(z.Elem()))
+}
+
+// Equal returns true if z and x have the same weight for every record.
+func (z ZSet) Equal(x ZSet) bool {
+	return z.Elem().Compare(x.Elem()) == 0
+}
+
+// String returns the canonical representation of z, e.g. 2·a - b.
+func (z ZSet) String() string {
+	return z.Elem().String()
+}
+
+// Filter returns the records of z satisfying pred, with their weights.
+//
+// Filter is linear, so it can be applied to deltas directly.
+func (z ZSet) Filter(pred func(r interface{}) bool) ZSet {
+	terms := make([]set.Term, 0, len(z.sum))
+	for _, t := range z.sum {
+		if pred(t.Sym) {
+			terms = append(terms, t)
+		}
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// Map returns the image of z under f, adding up the weights
+// of records mapped to the same record.
+//
+// Map is linear, so it can be applied to deltas directly.
+func (z ZSet) Map(f func(r interface{}) interface{}) ZSet {
+	terms := make([]set.Term, len(z.sum))
+	for i, t := range z.sum {
+		terms[i] = set.Term{Coef: t.Coef, Sym: f(t.Sym)}
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// Pair is a record made of two records, e.g. the result of a Join
+// or a (key, value) result of Aggregate.
+type Pair struct {
+	First, Second interface{}
+}
+
+// Join returns the equi-join of a and b on keyA(x) == keyB(y), where
+// the weight of a joined record is the product of the weights.
+// Joined records are combine(x, y), or Pair{x, y} if combine is nil.
+//
+// Join is bilinear, see IncJoin for the incremental version.
+func Join(a, b ZSet, keyA, keyB func(r interface{}) interface{}, combine func(x, y interface{}) interface{}) ZSet {
+	if combine == nil {
+		combine = func(x, y interface{}) interface{} { return Pair{First: x, Second: y} }
+	}
+	index := make(map[interface{}][]set.Term)
+	for _, t := range b.sum {
+		k := keyB(t.Sym)
+		index[k] = append(index[k], t)
+	}
+	var terms []set.Term
+	for _, x := range a.sum {
+		for _, y := range index[keyA(x.Sym)] {
+			terms = append(terms, set.Term{Coef: x.Coef * y.Coef, Sym: combine(x.Sym, y.Sym)})
+		}
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// Distinct returns the set of records with positive weight in z,
+// each with weight 1.
+//
+// Distinct is not linear, see IncDistinct for the incremental version.
+func (z ZSet) Distinct() ZSet {
+	terms := make([]set.Term, 0, len(z.sum))
+	for _, t := range z.sum {
+		if t.Coef > 0 {
+			terms = append(terms, set.Term{Coef: 1, Sym: t.Sym})
+		}
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// Aggregate groups the records of z by key, and returns the Z-set of
+// Pair{k, agg(k, group)} with weight 1 for each key k with a nonempty group.
+//
+// Aggregate is not linear, see IncAggregate for the incremental version.
+func (z ZSet) Aggregate(key func(r interface{}) interface{}, agg func(k interface{}, group ZSet) interface{}) ZSet {
+	groups := z.groups(key)
+	terms := make([]set.Term, 0, len(groups))
+	for k, g := range groups {
+		terms = append(terms, set.Term{Coef: 1, Sym: Pair{First: k, Second: agg(k, g)}})
+	}
+	return ZSet{sum: Set.Sum(terms...)}
+}
+
+// groups partitions z by key.
+func (z ZSet) groups(key func(r interface{}) interface{}) map[interface{}]ZSet {
+	parts := make(map[interface{}][]set.Term)
+	for _, t := range z.sum {
+		k := key(t.Sym)
+		parts[k] = append(parts[k], t)
+	}
+	groups := make(map[interface{}]ZSet, len(parts))
+	for k, terms := range parts {
+		groups[k] = ZSet{sum: Set.Sum(terms...)}
+	}
+	return groups
+}
+
+// Sum is an aggregate function for Aggregate, which returns the sum of
+// value(r) multiplied by the weight of r over a group.
+func Sum(value func(r interface{}) int) func(k interface{}, group ZSet) interface{} {
+	return func(k interface{}, group ZSet) interface{} {
+		sum := 0
+		for _, t := range group.sum {
+			sum += t.Coef * value(t.Sym)
+		}
+		return sum
+	}
+}
+
+// Count is an aggregate function for Aggregate, which returns
+// the total weight of a group.
+func Count(k interface{}, group ZSet) interface{} {
+	count := 0
+	for _, t := range group.sum {
+		count += t.Coef
+	}
+	return count
+}
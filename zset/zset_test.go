@@ -0,0 +1,126 @@
+package zset
+
+import (
+	"testing"
+)
+
+func TestGroupOps(t *testing.T) {
+	a := Of("x", "x", "y")
+	b := Of("y", "z")
+	if want, got := "2·x + 2·y + z", a.Add(b).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if want, got := "2·x - z", a.Sub(b).String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	if !a.Add(a.Neg()).IsZero() {
+		t.Errorf("expected a - a to be zero")
+	}
+	if want, got := 2, a.Weight("x"); want != got {
+		t.Errorf("expected weight %d but got %d", want, got)
+	}
+	g := Group()
+	if !FromElem(g.Op(a.Elem(), b.Elem())).Equal(a.Add(b)) {
+		t.Errorf("expected group operation to be +")
+	}
+	var zero ZSet
+	if !zero.Add(a).Equal(a) {
+		t.Errorf("expected zero value to be the identity")
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	z := Of(1, 2, 3, 4, 4).Sub(Of(5))
+	even := z.Filter(func(r interface{}) bool { return r.(int)%2 == 0 })
+	if want, got := "2 + 2·4", even.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	mod := z.Map(func(r interface{}) interface{} { return r.(int) % 2 })
+	if want, got := "3·0 + 1", mod.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+type person struct {
+	name string
+	city int
+}
+
+type city struct {
+	id   int
+	name string
+}
+
+func TestJoin(t *testing.T) {
+	people := Of(person{"ann", 1}, person{"bob", 2}, person{"cat", 1})
+	cities := Of(city{1, "london"}, city{3, "paris"})
+	j := Join(people, cities,
+		func(r interface{}) interface{} { return r.(person).city },
+		func(r interface{}) interface{} { return r.(city).id },
+		func(x, y interface{}) interface{} { return x.(person).name + "@" + y.(city).name })
+	if want, got := "ann@london + cat@london", j.String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+}
+
+func TestDistinctAggregate(t *testing.T) {
+	z := Of("a", "a", "b").Sub(Of("c"))
+	if want, got := "a + b", z.Distinct().String(); want != got {
+		t.Errorf("expected %s but got %s", want, got)
+	}
+	byLen := z.Add(Of("dd")).Aggregate(func(r interface{}) interface{} { return len(r.(string)) }, Count)
+	if !byLen.Equal(Of(Pair{1, 2}, Pair{2, 1})) {
+		t.Errorf("expected counts {1 2} + {2 1} but got %s", byLen)
+	}
+}
+
+func TestSamePrintRecords(t *testing.T) {
+	x, y := Pair{1, "1"}, Pair{"1", 1}
+	z := Of(x, y, x)
+	if want, got := 2, z.Len(); want != got {
+		t.Errorf("expected %d records in %v but got %d", want, z, got)
+	}
+	if want, got := 2, z.Weight(x); want != got {
+		t.Errorf("expected weight %d of %#v but got %d", want, x, got)
+	}
+	if want, got := 1, z.Weight(y); want != got {
+		t.Errorf("expected weight %d of %#v but got %d", want, y, got)
+	}
+	if Of(x).Equal(Of(y)) {
+		t.Errorf("expected %#v and %#v to be different records", x, y)
+	}
+	if want, got := 2, z.Sub(Of(y)).Weight(x); want != got || z.Sub(Of(y)).Len() != 1 {
+		t.Errorf("expected only %#v to remain but got %v", x, z.Sub(Of(y)))
+	}
+	keys := z.Aggregate(func(r interface{}) interface{} { return r }, Count)
+	if want, got := 2, keys.Len(); want != got {
+		t.Errorf("expected %d groups in %v but got %d", want, keys, got)
+	}
+}
+
+func TestMixedRecords(t *testing.T) {
+	x, y := Of(2, 10, "15"), Of("15", 2, 10)
+	if !x.Equal(y) || x.String() != y.String() {
+		t.Errorf("expected %v and %v to be equal", x, y)
+	}
+	if d := x.Sub(y); !d.IsZero() {
+		t.Errorf("expected x - y = 0 but got %v", d)
+	}
+	if d := x.Sub(x); !d.IsZero() {
+		t.Errorf("expected x - x = 0 but got %v", d)
+	}
+	if want, got := x, Of(10, "15", "15", 2).Distinct(); !want.Equal(got) {
+		t.Errorf("expected distinct records %v but got %v", want, got)
+	}
+	var view IncDistinct
+	out := view.Step(Of("15", 2)).Add(view.Step(Of(10, 2, "15")))
+	if !x.Equal(out) {
+		t.Errorf("expected incremental distinct %v but got %v", x, out)
+	}
+	if d := view.Step(Of(2, "15").Neg()); !d.IsZero() {
+		t.Errorf("expected no change but got %v", d)
+	}
+	if want, got := Of(10).Neg(), view.Step(Of(10).Neg()); !want.Equal(got) {
+		t.Errorf("expected change %v but got %v", want, got)
+	}
+}